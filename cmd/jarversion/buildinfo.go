@@ -0,0 +1,85 @@
+package main
+
+import (
+	"runtime"
+	"runtime/debug"
+	"strings"
+)
+
+// toolVersion, gitRevision and buildDate are populated at build time via
+// -ldflags, e.g.:
+//
+//	go build -ldflags "-X main.toolVersion=1.4.0 -X main.gitRevision=$(git rev-parse HEAD) -X main.buildDate=$(date -u +%Y-%m-%dT%H:%M:%SZ)"
+//
+// When the binary was built with `go build` directly (no ldflags), the
+// fallback in currentBuildInfo reads the embedded VCS stamp instead.
+var (
+	toolVersion = "dev"
+	gitRevision string
+	buildDate   string
+)
+
+// BuildInfo is the structured form of the --version report.
+type BuildInfo struct {
+	ToolVersion string `json:"tool_version"`
+	GitRevision string `json:"git_revision,omitempty"`
+	BuildDate   string `json:"build_date,omitempty"`
+	GoVersion   string `json:"go_version"`
+	OS          string `json:"os"`
+	Arch        string `json:"arch"`
+	Modified    bool   `json:"modified,omitempty"`
+}
+
+// currentBuildInfo assembles the --version report from the ldflags-injected
+// variables, falling back to runtime/debug.ReadBuildInfo's embedded VCS
+// stamp for git revision and build date when those were not set.
+func currentBuildInfo() BuildInfo {
+	info := BuildInfo{
+		ToolVersion: toolVersion,
+		GitRevision: gitRevision,
+		BuildDate:   buildDate,
+		GoVersion:   runtime.Version(),
+		OS:          runtime.GOOS,
+		Arch:        runtime.GOARCH,
+	}
+
+	if info.GitRevision == "" || info.BuildDate == "" {
+		if bi, ok := debug.ReadBuildInfo(); ok {
+			for _, setting := range bi.Settings {
+				switch setting.Key {
+				case "vcs.revision":
+					if info.GitRevision == "" {
+						info.GitRevision = setting.Value
+					}
+				case "vcs.time":
+					if info.BuildDate == "" {
+						info.BuildDate = setting.Value
+					}
+				case "vcs.modified":
+					info.Modified = setting.Value == "true"
+				}
+			}
+		}
+	}
+	return info
+}
+
+// String renders the report as the multi-line text shown by --version.
+func (b BuildInfo) String() string {
+	lines := []string{"jarversion CLI tool version: " + b.ToolVersion}
+
+	if b.GitRevision != "" {
+		rev := b.GitRevision
+		if b.Modified {
+			rev += " (modified)"
+		}
+		lines = append(lines, "Git revision: "+rev)
+	}
+	if b.BuildDate != "" {
+		lines = append(lines, "Build date: "+b.BuildDate)
+	}
+	lines = append(lines, "Go version: "+b.GoVersion)
+	lines = append(lines, "OS/Arch: "+b.OS+"/"+b.Arch)
+
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,29 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCurrentBuildInfo(t *testing.T) {
+	info := currentBuildInfo()
+	if info.ToolVersion != toolVersion {
+		t.Errorf("expected ToolVersion %q, got %q", toolVersion, info.ToolVersion)
+	}
+	if info.GoVersion == "" {
+		t.Errorf("expected GoVersion to be populated")
+	}
+	if info.OS == "" || info.Arch == "" {
+		t.Errorf("expected OS/Arch to be populated, got %q/%q", info.OS, info.Arch)
+	}
+}
+
+func TestBuildInfo_String(t *testing.T) {
+	b := BuildInfo{ToolVersion: "1.2.3", GitRevision: "abc123", Modified: true, GoVersion: "go1.22", OS: "linux", Arch: "amd64"}
+	s := b.String()
+	for _, want := range []string{"1.2.3", "abc123 (modified)", "go1.22", "linux/amd64"} {
+		if !strings.Contains(s, want) {
+			t.Errorf("expected String() to contain %q, got:\n%s", want, s)
+		}
+	}
+}
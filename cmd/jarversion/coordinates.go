@@ -0,0 +1,179 @@
+package main
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Coordinate identifies a Maven/Gradle artifact by its group, artifact and
+// version (GAV) triple. A Coordinate with Error set instead records a
+// maven metadata entry that failed to parse; GroupID/ArtifactID/Version
+// are left empty in that case.
+type Coordinate struct {
+	GroupID    string `json:"group_id"`
+	ArtifactID string `json:"artifact_id"`
+	Version    string `json:"version"`
+	Error      string `json:"error,omitempty"`
+}
+
+// String renders the coordinate in the canonical "group:artifact:version"
+// form.
+func (c Coordinate) String() string {
+	return fmt.Sprintf("%s:%s:%s", c.GroupID, c.ArtifactID, c.Version)
+}
+
+// pomXML is the minimal subset of a Maven POM needed to recover a
+// coordinate when pom.properties is not present in the JAR.
+type pomXML struct {
+	XMLName    xml.Name `xml:"project"`
+	GroupID    string   `xml:"groupId"`
+	ArtifactID string   `xml:"artifactId"`
+	Version    string   `xml:"version"`
+	Parent     struct {
+		GroupID string `xml:"groupId"`
+		Version string `xml:"version"`
+	} `xml:"parent"`
+}
+
+// extractCoordinates scans a JAR for Maven coordinate metadata. It prefers
+// META-INF/maven/<groupId>/<artifactId>/pom.properties, and falls back to
+// parsing the sibling pom.xml when pom.properties is absent. Shaded/uber
+// JARs may embed more than one coordinate, one per bundled dependency.
+//
+// A malformed or truncated metadata entry does not abort the scan: it is
+// recorded as a Coordinate with Error set (mirroring NestedArchive.Error)
+// so the rest of the JAR's coordinates are still reported, which matters
+// for uber JARs bundling many dependencies where one bad entry is
+// plausible.
+func extractCoordinates(r *zip.Reader) []Coordinate {
+	var coords []Coordinate
+	resolved := 0
+
+	for _, f := range r.File {
+		if !isMavenMetadataPath(f.Name, "pom.properties") {
+			continue
+		}
+		c, err := readZipEntry(f, parsePomProperties)
+		if err != nil {
+			coords = append(coords, Coordinate{Error: fmt.Sprintf("failed to parse %s: %v", f.Name, err)})
+			continue
+		}
+		if c.GroupID != "" || c.ArtifactID != "" {
+			coords = append(coords, c)
+			resolved++
+		}
+	}
+	if resolved > 0 {
+		return coords
+	}
+
+	// No pom.properties resolved; coords now holds only their Error
+	// records, which are kept alongside the pom.xml results.
+	for _, f := range r.File {
+		if !isMavenMetadataPath(f.Name, "pom.xml") {
+			continue
+		}
+		c, err := readZipEntry(f, parsePomXML)
+		if err != nil {
+			coords = append(coords, Coordinate{Error: fmt.Sprintf("failed to parse %s: %v", f.Name, err)})
+			continue
+		}
+		if c.GroupID != "" || c.ArtifactID != "" {
+			coords = append(coords, c)
+		}
+	}
+	return coords
+}
+
+// countResolved reports how many coordinates in coords were successfully
+// extracted, excluding entries that only record a parse Error.
+func countResolved(coords []Coordinate) int {
+	n := 0
+	for _, c := range coords {
+		if c.Error == "" {
+			n++
+		}
+	}
+	return n
+}
+
+// isMavenMetadataPath reports whether name matches
+// META-INF/maven/<groupId>/<artifactId>/<file>.
+func isMavenMetadataPath(name, file string) bool {
+	parts := strings.Split(name, "/")
+	return len(parts) == 5 && parts[0] == "META-INF" && parts[1] == "maven" && parts[4] == file
+}
+
+// readZipEntry opens a zip entry, reads it fully and passes the bytes to
+// parse.
+func readZipEntry(f *zip.File, parse func([]byte) (Coordinate, error)) (Coordinate, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return Coordinate{}, err
+	}
+	defer rc.Close()
+
+	data, err := io.ReadAll(rc)
+	if err != nil {
+		return Coordinate{}, err
+	}
+	return parse(data)
+}
+
+// parsePomProperties parses the Java properties format written by the
+// maven-jar-plugin into pom.properties: simple "key=value" lines, with
+// "#" comments and optional surrounding whitespace.
+func parsePomProperties(data []byte) (Coordinate, error) {
+	var c Coordinate
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "!") {
+			continue
+		}
+		idx := strings.IndexAny(line, "=:")
+		if idx < 0 {
+			continue
+		}
+		key := strings.TrimSpace(line[:idx])
+		value := strings.TrimSpace(line[idx+1:])
+		switch key {
+		case "groupId":
+			c.GroupID = value
+		case "artifactId":
+			c.ArtifactID = value
+		case "version":
+			c.Version = value
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return Coordinate{}, err
+	}
+	return c, nil
+}
+
+// parsePomXML parses an embedded pom.xml, falling back to the parent
+// groupId/version when the project does not declare its own.
+func parsePomXML(data []byte) (Coordinate, error) {
+	var p pomXML
+	if err := xml.Unmarshal(data, &p); err != nil {
+		return Coordinate{}, err
+	}
+	c := Coordinate{
+		GroupID:    p.GroupID,
+		ArtifactID: p.ArtifactID,
+		Version:    p.Version,
+	}
+	if c.GroupID == "" {
+		c.GroupID = p.Parent.GroupID
+	}
+	if c.Version == "" {
+		c.Version = p.Parent.Version
+	}
+	return c, nil
+}
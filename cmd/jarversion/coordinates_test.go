@@ -0,0 +1,143 @@
+package main
+
+import (
+	"archive/zip"
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParsePomProperties(t *testing.T) {
+	data := []byte(`#Generated by Maven
+#Mon Jan 01 00:00:00 UTC 2024
+version=1.2.3
+groupId=com.example
+artifactId=widget
+`)
+	c, err := parsePomProperties(data)
+	if err != nil {
+		t.Fatalf("parsePomProperties failed: %v", err)
+	}
+	want := Coordinate{GroupID: "com.example", ArtifactID: "widget", Version: "1.2.3"}
+	if c != want {
+		t.Errorf("expected %+v, got %+v", want, c)
+	}
+	if c.String() != "com.example:widget:1.2.3" {
+		t.Errorf("unexpected String(): %s", c.String())
+	}
+}
+
+func TestParsePomXML_FallsBackToParent(t *testing.T) {
+	data := []byte(`<project>
+  <parent>
+    <groupId>com.example</groupId>
+    <version>2.0.0</version>
+  </parent>
+  <artifactId>widget</artifactId>
+</project>`)
+	c, err := parsePomXML(data)
+	if err != nil {
+		t.Fatalf("parsePomXML failed: %v", err)
+	}
+	want := Coordinate{GroupID: "com.example", ArtifactID: "widget", Version: "2.0.0"}
+	if c != want {
+		t.Errorf("expected %+v, got %+v", want, c)
+	}
+}
+
+func TestIsMavenMetadataPath(t *testing.T) {
+	if !isMavenMetadataPath("META-INF/maven/com.example/widget/pom.properties", "pom.properties") {
+		t.Errorf("expected path to match")
+	}
+	if isMavenMetadataPath("META-INF/MANIFEST.MF", "pom.properties") {
+		t.Errorf("expected unrelated path not to match")
+	}
+}
+
+func TestExtractCoordinates_FatJar(t *testing.T) {
+	jar := buildJarBytes(t, "Implementation-Version: 1.0.0\n", map[string][]byte{
+		"META-INF/maven/com.example/widget/pom.properties": []byte(
+			"groupId=com.example\nartifactId=widget\nversion=1.2.3\n"),
+		"META-INF/maven/com.example/gadget/pom.properties": []byte(
+			"groupId=com.example\nartifactId=gadget\nversion=4.5.6\n"),
+	})
+
+	r, err := zip.NewReader(bytes.NewReader(jar), int64(len(jar)))
+	if err != nil {
+		t.Fatalf("failed to open zip: %v", err)
+	}
+
+	coords := extractCoordinates(r)
+	if len(coords) != 2 {
+		t.Fatalf("expected 2 coordinates, got %d: %+v", len(coords), coords)
+	}
+	if countResolved(coords) != 2 {
+		t.Errorf("expected both coordinates to resolve, got %+v", coords)
+	}
+}
+
+func TestExtractCoordinates_BadEntryDoesNotHideOthers(t *testing.T) {
+	// No pom.properties at all, so extraction falls back to pom.xml: one
+	// entry is truncated/malformed, the other is a valid embedded POM.
+	jar := buildJarBytes(t, "Implementation-Version: 1.0.0\n", map[string][]byte{
+		"META-INF/maven/com.example/broken/pom.xml": []byte("<project><groupId"),
+		"META-INF/maven/com.example/widget/pom.xml": []byte(
+			"<project><groupId>com.example</groupId><artifactId>widget</artifactId><version>1.2.3</version></project>"),
+	})
+
+	r, err := zip.NewReader(bytes.NewReader(jar), int64(len(jar)))
+	if err != nil {
+		t.Fatalf("failed to open zip: %v", err)
+	}
+
+	coords := extractCoordinates(r)
+	if countResolved(coords) != 1 {
+		t.Fatalf("expected the valid pom.xml entry to still resolve, got %+v", coords)
+	}
+	var sawError bool
+	for _, c := range coords {
+		if c.Error != "" {
+			sawError = true
+		}
+	}
+	if !sawError {
+		t.Errorf("expected the malformed pom.xml to be recorded as an error, got %+v", coords)
+	}
+}
+
+func TestRunCLI_CoordinatesFlag_FatJar(t *testing.T) {
+	jar := buildJarBytes(t, "Implementation-Version: 1.0.0\n", map[string][]byte{
+		"META-INF/maven/com.example/widget/pom.properties": []byte(
+			"groupId=com.example\nartifactId=widget\nversion=1.2.3\n"),
+		"META-INF/maven/com.example/gadget/pom.properties": []byte(
+			"groupId=com.example\nartifactId=gadget\nversion=4.5.6\n"),
+	})
+
+	jarPath := filepath.Join(t.TempDir(), "fat.jar")
+	if err := os.WriteFile(jarPath, jar, 0644); err != nil {
+		t.Fatalf("failed to write jar: %v", err)
+	}
+
+	var out bytes.Buffer
+	if err := RunCLI([]string{"--coordinates", "--json", jarPath}, &out); err != nil {
+		t.Fatalf("RunCLI failed: %v", err)
+	}
+
+	output := out.String()
+	if !strings.Contains(output, `"fat_jar": true`) {
+		t.Errorf("expected fat_jar to be true in output, got: %s", output)
+	}
+	if !strings.Contains(output, `"artifact_id": "widget"`) || !strings.Contains(output, `"artifact_id": "gadget"`) {
+		t.Errorf("expected both coordinates in output, got: %s", output)
+	}
+
+	out.Reset()
+	if err := RunCLI([]string{"--gav", "--json", jarPath}, &out); err != nil {
+		t.Fatalf("RunCLI with --gav failed: %v", err)
+	}
+	if !strings.Contains(out.String(), `"fat_jar": true`) {
+		t.Errorf("expected --gav to behave like --coordinates, got: %s", out.String())
+	}
+}
@@ -0,0 +1,287 @@
+package main
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/mikevdberge/jarversion-cli/manifest"
+)
+
+// newHasher returns a fresh hash.Hash for the given algorithm name, matched
+// case-insensitively. Supported algorithms: md5, sha1, sha256, sha512.
+func newHasher(algo string) (hash.Hash, error) {
+	switch strings.ToLower(algo) {
+	case "md5":
+		return md5.New(), nil
+	case "sha1":
+		return sha1.New(), nil
+	case "sha256":
+		return sha256.New(), nil
+	case "sha512":
+		return sha512.New(), nil
+	default:
+		return nil, fmt.Errorf("unsupported digest algorithm %q", algo)
+	}
+}
+
+// computeDigests streams the file at path once through an io.MultiWriter of
+// hashers, one per requested algorithm, and returns each digest hex-encoded
+// keyed by the (lower-cased) algorithm name.
+func computeDigests(path string, algos []string) (map[string]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open JAR file for hashing: %w", err)
+	}
+	defer file.Close()
+
+	return computeDigestsReader(file, algos)
+}
+
+// computeDigestsFromBytes is computeDigests for data already held in
+// memory, e.g. a nested JAR entry that was read out of its parent archive.
+func computeDigestsFromBytes(data []byte, algos []string) (map[string]string, error) {
+	return computeDigestsReader(bytes.NewReader(data), algos)
+}
+
+// computeDigestsReader streams r once through an io.MultiWriter of
+// hashers, one per requested algorithm, and returns each digest
+// hex-encoded keyed by the (lower-cased) algorithm name.
+func computeDigestsReader(r io.Reader, algos []string) (map[string]string, error) {
+	hashers := make(map[string]hash.Hash, len(algos))
+	writers := make([]io.Writer, 0, len(algos))
+	for _, algo := range algos {
+		h, err := newHasher(algo)
+		if err != nil {
+			return nil, err
+		}
+		key := strings.ToLower(algo)
+		hashers[key] = h
+		writers = append(writers, h)
+	}
+
+	if _, err := io.Copy(io.MultiWriter(writers...), r); err != nil {
+		return nil, fmt.Errorf("failed to compute digests: %w", err)
+	}
+
+	digests := make(map[string]string, len(hashers))
+	for algo, h := range hashers {
+		digests[algo] = fmt.Sprintf("%x", h.Sum(nil))
+	}
+	return digests, nil
+}
+
+// parseDigestAlgos splits a comma-separated --digest flag value into a
+// cleaned list of algorithm names.
+func parseDigestAlgos(value string) []string {
+	var algos []string
+	for _, algo := range strings.Split(value, ",") {
+		algo = strings.TrimSpace(algo)
+		if algo != "" {
+			algos = append(algos, algo)
+		}
+	}
+	return algos
+}
+
+// DigestMismatch describes a digest that does not match the value recorded
+// for it: a JAR entry against its MANIFEST.MF SHA-256-Digest, or (Entry
+// "MANIFEST.MF" or "MANIFEST.MF#<name>") the manifest or one of its
+// sections against the digest recorded in the META-INF/*.SF file.
+type DigestMismatch struct {
+	Entry    string `json:"entry"`
+	Expected string `json:"expected"`
+	Actual   string `json:"actual"`
+}
+
+// VerifyReport is the result of verifying a signed JAR's per-entry digests
+// against the values recorded in its MANIFEST.MF, plus (when present) the
+// META-INF/*.SF signature file's digests over the manifest itself.
+type VerifyReport struct {
+	SignatureFile  string           `json:"signature_file,omitempty"`
+	EntriesChecked int              `json:"entries_checked"`
+	Mismatches     []DigestMismatch `json:"mismatches,omitempty"`
+}
+
+// OK reports whether every checked entry's digest matched.
+func (r *VerifyReport) OK() bool {
+	return len(r.Mismatches) == 0
+}
+
+// VerifyJar checks the tamper-evidence chain of a signed JAR's entries:
+//
+//  1. Every per-entry MANIFEST.MF section's SHA-256-Digest is recomputed
+//     against the live zip entry's bytes, catching a tampered or missing
+//     class/resource file.
+//  2. If a META-INF/*.SF signature file is present, its
+//     SHA-256-Digest-Manifest is recomputed against the whole MANIFEST.MF
+//     and its per-entry SHA-256-Digest attributes are recomputed against
+//     the corresponding raw manifest section bytes, catching a tampered or
+//     stripped MANIFEST.MF that step 1 alone would not detect.
+//
+// VerifyJar does not validate the cryptographic signature over the .SF
+// file itself (the .RSA/.DSA block); it only recomputes the digests the
+// .SF file and MANIFEST.MF record. A JAR with no signature file is
+// reported with SignatureFile empty and only step 1 is performed.
+func VerifyJar(r *zip.Reader) (*VerifyReport, error) {
+	mfFile, err := findEntry(r, "META-INF/MANIFEST.MF")
+	if err != nil {
+		return nil, err
+	}
+	if mfFile == nil {
+		return nil, fmt.Errorf("MANIFEST.MF not found in JAR file")
+	}
+
+	data, err := readZipEntryBytes(mfFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read MANIFEST.MF: %w", err)
+	}
+
+	mf, err := manifest.Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse MANIFEST.MF: %w", err)
+	}
+
+	report := &VerifyReport{}
+	for _, entry := range mf.Entries() {
+		expected, ok := entry.Attributes.Get("SHA-256-Digest")
+		if !ok {
+			continue
+		}
+
+		f, err := findEntry(r, entry.Name)
+		if err != nil {
+			return nil, err
+		}
+		if f == nil {
+			report.Mismatches = append(report.Mismatches, DigestMismatch{
+				Entry:    entry.Name,
+				Expected: expected,
+				Actual:   "(entry not found in JAR)",
+			})
+			continue
+		}
+
+		contents, err := readZipEntryBytes(f)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", entry.Name, err)
+		}
+
+		sum := sha256.Sum256(contents)
+		actual := base64.StdEncoding.EncodeToString(sum[:])
+
+		report.EntriesChecked++
+		if actual != expected {
+			report.Mismatches = append(report.Mismatches, DigestMismatch{
+				Entry:    entry.Name,
+				Expected: expected,
+				Actual:   actual,
+			})
+		}
+	}
+
+	if sfFile := findSignatureFile(r); sfFile != nil {
+		report.SignatureFile = sfFile.Name
+		if err := verifySignatureFile(sfFile, data, report); err != nil {
+			return nil, err
+		}
+	}
+
+	return report, nil
+}
+
+// verifySignatureFile parses sfFile as a META-INF/*.SF signature file and
+// checks its recorded digests against manifestData, the raw bytes of
+// MANIFEST.MF, appending any mismatch to report.
+func verifySignatureFile(sfFile *zip.File, manifestData []byte, report *VerifyReport) error {
+	sfData, err := readZipEntryBytes(sfFile)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", sfFile.Name, err)
+	}
+
+	sf, err := manifest.Parse(sfData)
+	if err != nil {
+		return fmt.Errorf("failed to parse %s: %w", sfFile.Name, err)
+	}
+
+	if expected, ok := sf.Get("SHA-256-Digest-Manifest"); ok {
+		sum := sha256.Sum256(manifestData)
+		if actual := base64.StdEncoding.EncodeToString(sum[:]); actual != expected {
+			report.Mismatches = append(report.Mismatches, DigestMismatch{
+				Entry:    "MANIFEST.MF",
+				Expected: expected,
+				Actual:   actual,
+			})
+		}
+	}
+
+	for _, sec := range sf.Entries() {
+		expected, ok := sec.Attributes.Get("SHA-256-Digest")
+		if !ok {
+			continue
+		}
+
+		raw, ok := manifest.RawSection(manifestData, sec.Name)
+		if !ok {
+			report.Mismatches = append(report.Mismatches, DigestMismatch{
+				Entry:    "MANIFEST.MF#" + sec.Name,
+				Expected: expected,
+				Actual:   "(manifest section not found)",
+			})
+			continue
+		}
+
+		sum := sha256.Sum256(raw)
+		if actual := base64.StdEncoding.EncodeToString(sum[:]); actual != expected {
+			report.Mismatches = append(report.Mismatches, DigestMismatch{
+				Entry:    "MANIFEST.MF#" + sec.Name,
+				Expected: expected,
+				Actual:   actual,
+			})
+		}
+	}
+
+	return nil
+}
+
+// findSignatureFile returns the first META-INF/*.SF entry found in r, or
+// nil if the JAR carries no signature file.
+func findSignatureFile(r *zip.Reader) *zip.File {
+	for _, f := range r.File {
+		upper := strings.ToUpper(f.Name)
+		if strings.HasPrefix(upper, "META-INF/") && strings.HasSuffix(upper, ".SF") {
+			return f
+		}
+	}
+	return nil
+}
+
+// findEntry returns the zip.File with the given name, matched
+// case-insensitively, or nil if not present.
+func findEntry(r *zip.Reader, name string) (*zip.File, error) {
+	for _, f := range r.File {
+		if strings.EqualFold(f.Name, name) {
+			return f, nil
+		}
+	}
+	return nil, nil
+}
+
+// readZipEntryBytes opens and fully reads a zip entry's contents.
+func readZipEntryBytes(f *zip.File) ([]byte, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	return io.ReadAll(rc)
+}
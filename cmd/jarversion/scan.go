@@ -0,0 +1,251 @@
+package main
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// scanConfig bundles the --jobs/--ndjson/--filter/--fail-on-* flags
+// controlling a directory scan.
+type scanConfig struct {
+	jobs                 int
+	ndjson               bool
+	filter               string
+	digestAlgos          []string
+	jsonFile             string
+	failOnMissingVersion bool
+	failOnError          bool
+}
+
+// runScan walks root for JAR/WAR/EAR archives, extracts version info from
+// each, writes the aggregated results to stdout (or cfg.jsonFile) as a
+// JSON array or NDJSON, and returns an error — for a non-zero exit code —
+// when the configured fail-on conditions are met.
+func runScan(root string, stdout io.Writer, cfg scanConfig) error {
+	results, err := scanDirectory(root, cfg.jobs, cfg.filter, cfg.digestAlgos)
+	if err != nil {
+		return err
+	}
+
+	if err := writeScanResults(results, stdout, cfg); err != nil {
+		return err
+	}
+
+	var missingVersion, failed int
+	for _, r := range results {
+		if r.Error != "" {
+			failed++
+			continue
+		}
+		if r.VersionInfo.ImplementationVersion == "" {
+			missingVersion++
+		}
+	}
+
+	if cfg.failOnError && failed > 0 {
+		return fmt.Errorf("%d of %d archives failed to parse", failed, len(results))
+	}
+	if cfg.failOnMissingVersion && missingVersion > 0 {
+		return fmt.Errorf("%d of %d archives have no Implementation-Version", missingVersion, len(results))
+	}
+	return nil
+}
+
+// writeScanResults renders results as NDJSON or a single JSON array, to
+// cfg.jsonFile when set or to stdout otherwise.
+func writeScanResults(results []ScanResult, stdout io.Writer, cfg scanConfig) error {
+	var out io.Writer = stdout
+	var file *os.File
+	if cfg.jsonFile != "" {
+		f, err := os.Create(cfg.jsonFile)
+		if err != nil {
+			return fmt.Errorf("failed to create JSON file: %w", err)
+		}
+		defer f.Close()
+		file = f
+		out = f
+	}
+
+	if cfg.ndjson {
+		enc := json.NewEncoder(out)
+		for _, r := range results {
+			if err := enc.Encode(r); err != nil {
+				return fmt.Errorf("failed to encode result: %w", err)
+			}
+		}
+	} else {
+		data, err := json.MarshalIndent(results, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode results: %w", err)
+		}
+		if _, err := out.Write(append(data, '\n')); err != nil {
+			return fmt.Errorf("failed to write results: %w", err)
+		}
+	}
+
+	if file != nil {
+		fmt.Fprintf(stdout, "✅ Scan results written to %s\n", cfg.jsonFile)
+	}
+	return nil
+}
+
+// ScanResult is the outcome of scanning a single archive found while
+// walking a directory: its path, the extracted VersionInfo, or the error
+// encountered while reading it.
+type ScanResult struct {
+	Path        string       `json:"path"`
+	VersionInfo *VersionInfo `json:"version_info,omitempty"`
+	Error       string       `json:"error,omitempty"`
+}
+
+var scannableExtensions = map[string]bool{
+	".jar": true,
+	".war": true,
+	".ear": true,
+}
+
+// findArchives walks root collecting *.jar/*.war/*.ear paths, optionally
+// limited to filenames matching the filter glob.
+func findArchives(root, filter string) ([]string, error) {
+	var paths []string
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		if !scannableExtensions[strings.ToLower(filepath.Ext(path))] {
+			return nil
+		}
+		if filter != "" {
+			matched, err := filepath.Match(filter, filepath.Base(path))
+			if err != nil {
+				return err
+			}
+			if !matched {
+				return nil
+			}
+		}
+		paths = append(paths, path)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to walk %s: %w", root, err)
+	}
+	return paths, nil
+}
+
+// scanDirectory walks root for JAR/WAR/EAR archives and extracts version
+// info from each one using a pool of jobs workers. Progress is reported
+// on stderr when stdout is not a terminal, so a scan redirected to a file
+// or piped in CI still shows signs of life.
+func scanDirectory(root string, jobs int, filter string, digestAlgos []string) ([]ScanResult, error) {
+	if jobs <= 0 {
+		jobs = runtime.NumCPU()
+	}
+
+	paths, err := findArchives(root, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]ScanResult, len(paths))
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+
+	showProgress := len(paths) > 0 && !isTerminal(os.Stdout)
+	var completed int32
+
+	for w := 0; w < jobs; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				results[i] = scanArchive(paths[i], digestAlgos)
+				if showProgress {
+					n := atomic.AddInt32(&completed, 1)
+					fmt.Fprintf(os.Stderr, "\rScanned %d/%d archives", n, len(paths))
+				}
+			}
+		}()
+	}
+	for i := range paths {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	if showProgress {
+		fmt.Fprintln(os.Stderr)
+	}
+	return results, nil
+}
+
+// scanArchive extracts a VersionInfo from a single archive, reporting any
+// failure on the returned ScanResult rather than as a Go error so that one
+// bad archive does not abort the rest of the scan.
+func scanArchive(path string, digestAlgos []string) ScanResult {
+	info, err := extractArchiveVersionInfo(path, digestAlgos)
+	if err != nil {
+		return ScanResult{Path: path, Error: err.Error()}
+	}
+	return ScanResult{Path: path, VersionInfo: &info}
+}
+
+// extractArchiveVersionInfo opens path as a zip archive and extracts its
+// MANIFEST.MF version info, optionally alongside the requested digests.
+func extractArchiveVersionInfo(path string, digestAlgos []string) (VersionInfo, error) {
+	r, err := zip.OpenReader(path)
+	if err != nil {
+		return VersionInfo{}, fmt.Errorf("failed to open archive: %w", err)
+	}
+	defer r.Close()
+
+	for _, f := range r.File {
+		if !strings.EqualFold(f.Name, "META-INF/MANIFEST.MF") {
+			continue
+		}
+
+		rc, err := f.Open()
+		if err != nil {
+			return VersionInfo{}, fmt.Errorf("failed to open MANIFEST.MF: %w", err)
+		}
+		defer rc.Close()
+
+		data, err := io.ReadAll(rc)
+		if err != nil {
+			return VersionInfo{}, fmt.Errorf("failed to read MANIFEST.MF: %w", err)
+		}
+
+		info := ParseManifest(string(data))
+		if len(digestAlgos) > 0 {
+			digests, err := computeDigests(path, digestAlgos)
+			if err != nil {
+				return VersionInfo{}, err
+			}
+			info.Digests = digests
+		}
+		return info, nil
+	}
+	return VersionInfo{}, fmt.Errorf("MANIFEST.MF not found in archive")
+}
+
+// isTerminal reports whether f is connected to a character device, i.e. an
+// interactive terminal rather than a file or pipe.
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
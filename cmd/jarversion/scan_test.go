@@ -0,0 +1,104 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeTestArchive(t *testing.T, dir, name, manifestContent string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	jarPath := createTestJar(t, manifestContent)
+	data, err := os.ReadFile(jarPath)
+	if err != nil {
+		t.Fatalf("failed to read generated jar: %v", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatalf("failed to write archive: %v", err)
+	}
+	return path
+}
+
+func TestScanDirectory(t *testing.T) {
+	dir := t.TempDir()
+	writeTestArchive(t, dir, "a.jar", "Implementation-Version: 1.0.0\n")
+	writeTestArchive(t, dir, "b.war", "Implementation-Version: 2.0.0\n")
+	if err := os.WriteFile(filepath.Join(dir, "ignore.txt"), []byte("nope"), 0644); err != nil {
+		t.Fatalf("failed to write non-archive file: %v", err)
+	}
+
+	results, err := scanDirectory(dir, 2, "", nil)
+	if err != nil {
+		t.Fatalf("scanDirectory failed: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("expected 2 scanned archives, got %d", len(results))
+	}
+	for _, r := range results {
+		if r.Error != "" {
+			t.Errorf("unexpected error for %s: %s", r.Path, r.Error)
+		}
+		if r.VersionInfo == nil || r.VersionInfo.ImplementationVersion == "" {
+			t.Errorf("expected version info for %s, got %+v", r.Path, r.VersionInfo)
+		}
+	}
+}
+
+func TestScanDirectory_Filter(t *testing.T) {
+	dir := t.TempDir()
+	writeTestArchive(t, dir, "keep.jar", "Implementation-Version: 1.0.0\n")
+	writeTestArchive(t, dir, "skip.jar", "Implementation-Version: 2.0.0\n")
+
+	results, err := scanDirectory(dir, 1, "keep.*", nil)
+	if err != nil {
+		t.Fatalf("scanDirectory failed: %v", err)
+	}
+	if len(results) != 1 || !strings.HasSuffix(results[0].Path, "keep.jar") {
+		t.Errorf("expected only keep.jar to match filter, got %+v", results)
+	}
+}
+
+func TestRunScan_FailOnMissingVersion(t *testing.T) {
+	dir := t.TempDir()
+	writeTestArchive(t, dir, "noversion.jar", "Manifest-Version: 1.0\n")
+
+	var out bytes.Buffer
+	err := runScan(dir, &out, scanConfig{jobs: 1, failOnMissingVersion: true})
+	if err == nil {
+		t.Fatalf("expected error when an archive has no Implementation-Version")
+	}
+
+	var decoded []ScanResult
+	if jsonErr := json.Unmarshal(out.Bytes(), &decoded); jsonErr != nil {
+		t.Fatalf("failed to decode scan output: %v", jsonErr)
+	}
+	if len(decoded) != 1 {
+		t.Fatalf("expected 1 result, got %d", len(decoded))
+	}
+}
+
+func TestRunScan_NDJSON(t *testing.T) {
+	dir := t.TempDir()
+	writeTestArchive(t, dir, "a.jar", "Implementation-Version: 1.0.0\n")
+	writeTestArchive(t, dir, "b.jar", "Implementation-Version: 2.0.0\n")
+
+	var out bytes.Buffer
+	if err := runScan(dir, &out, scanConfig{jobs: 2, ndjson: true}); err != nil {
+		t.Fatalf("runScan failed: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 NDJSON lines, got %d", len(lines))
+	}
+	for _, line := range lines {
+		var r ScanResult
+		if err := json.Unmarshal([]byte(line), &r); err != nil {
+			t.Errorf("failed to decode NDJSON line %q: %v", line, err)
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"archive/zip"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/mikevdberge/jarversion-cli/version"
+)
+
+// extractImplementationVersion opens jarPath and returns the
+// Implementation-Version recorded in its MANIFEST.MF.
+func extractImplementationVersion(jarPath string) (string, error) {
+	r, err := zip.OpenReader(jarPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to open JAR file: %w", err)
+	}
+	defer r.Close()
+
+	for _, f := range r.File {
+		if !strings.EqualFold(f.Name, "META-INF/MANIFEST.MF") {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return "", fmt.Errorf("failed to open MANIFEST.MF: %w", err)
+		}
+		defer rc.Close()
+
+		data, err := io.ReadAll(rc)
+		if err != nil {
+			return "", fmt.Errorf("failed to read MANIFEST.MF: %w", err)
+		}
+
+		info := ParseManifest(string(data))
+		if info.ImplementationVersion == "" {
+			return "", fmt.Errorf("Implementation-Version not found in %s", jarPath)
+		}
+		return info.ImplementationVersion, nil
+	}
+	return "", fmt.Errorf("MANIFEST.MF not found in %s", jarPath)
+}
+
+// runCompare implements `jarversion compare <a.jar> <b.jar>`, printing
+// -1, 0 or 1 depending on how a's Implementation-Version compares to b's.
+func runCompare(args []string, stdout io.Writer) error {
+	if len(args) != 2 {
+		return fmt.Errorf("usage: jarversion compare <a.jar> <b.jar>")
+	}
+
+	aVersion, err := extractImplementationVersion(args[0])
+	if err != nil {
+		return err
+	}
+	bVersion, err := extractImplementationVersion(args[1])
+	if err != nil {
+		return err
+	}
+
+	a, err := version.Parse(aVersion)
+	if err != nil {
+		return err
+	}
+	b, err := version.Parse(bVersion)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(stdout, a.Compare(b))
+	return nil
+}
+
+// runBump implements `jarversion bump <major|minor|patch> <jar>`, printing
+// the next version after bumping the given segment.
+func runBump(args []string, stdout io.Writer) error {
+	if len(args) != 2 {
+		return fmt.Errorf("usage: jarversion bump <major|minor|patch> <jar>")
+	}
+
+	current, err := extractImplementationVersion(args[1])
+	if err != nil {
+		return err
+	}
+
+	v, err := version.Parse(current)
+	if err != nil {
+		return err
+	}
+
+	next, err := v.Next(version.Kind(args[0]))
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(stdout, next.String())
+	return nil
+}
+
+// runSatisfies implements `jarversion satisfies <jar> <constraint>`,
+// evaluating a constraint expression such as ">=1.2.0 <2.0.0" against the
+// JAR's Implementation-Version.
+func runSatisfies(args []string, stdout io.Writer) error {
+	if len(args) != 2 {
+		return fmt.Errorf("usage: jarversion satisfies <jar> <constraint>")
+	}
+
+	current, err := extractImplementationVersion(args[0])
+	if err != nil {
+		return err
+	}
+
+	v, err := version.Parse(current)
+	if err != nil {
+		return err
+	}
+
+	ok, err := version.Satisfies(v, args[1])
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(stdout, ok)
+	if !ok {
+		return fmt.Errorf("version %s does not satisfy %q", v, args[1])
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestRunCompare(t *testing.T) {
+	older := createTestJar(t, "Implementation-Version: 1.0.0\n")
+	newer := createTestJar(t, "Implementation-Version: 2.0.0\n")
+
+	var out bytes.Buffer
+	if err := runCompare([]string{older, newer}, &out); err != nil {
+		t.Fatalf("runCompare failed: %v", err)
+	}
+	if strings.TrimSpace(out.String()) != "-1" {
+		t.Errorf("expected -1, got %q", out.String())
+	}
+}
+
+func TestRunBump(t *testing.T) {
+	jar := createTestJar(t, "Implementation-Version: 1.2.3\n")
+
+	var out bytes.Buffer
+	if err := runBump([]string{"minor", jar}, &out); err != nil {
+		t.Fatalf("runBump failed: %v", err)
+	}
+	if strings.TrimSpace(out.String()) != "1.3.0" {
+		t.Errorf("expected 1.3.0, got %q", out.String())
+	}
+}
+
+func TestRunSatisfies(t *testing.T) {
+	jar := createTestJar(t, "Implementation-Version: 1.5.0\n")
+
+	var out bytes.Buffer
+	err := runSatisfies([]string{jar, ">=1.2.0 <2.0.0"}, &out)
+	if err != nil {
+		t.Fatalf("runSatisfies failed: %v", err)
+	}
+	if strings.TrimSpace(out.String()) != "true" {
+		t.Errorf("expected true, got %q", out.String())
+	}
+}
+
+func TestRunSatisfies_NotSatisfied(t *testing.T) {
+	jar := createTestJar(t, "Implementation-Version: 3.0.0\n")
+
+	var out bytes.Buffer
+	err := runSatisfies([]string{jar, ">=1.2.0 <2.0.0"}, &out)
+	if err == nil {
+		t.Fatalf("expected error when constraint is not satisfied")
+	}
+}
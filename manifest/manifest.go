@@ -0,0 +1,266 @@
+// Package manifest implements a parser for the JAR manifest format as
+// described by the JDK specification (https://docs.oracle.com/javase/8/docs/technotes/guides/jar/jar.html#JAR_Manifest).
+//
+// Unlike a simple prefix scan over known keys, this parser understands the
+// 72-byte line continuation convention, the blank-line-separated main
+// section plus per-entry sections, and case-insensitive attribute names.
+package manifest
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// Attributes holds the name/value pairs of a single manifest section.
+// Attribute names are case-insensitive per the manifest spec, so lookups
+// are normalized to lower-case internally.
+type Attributes struct {
+	values map[string]string
+	names  map[string]string // lower-case name -> first-seen original case
+}
+
+func newAttributes() Attributes {
+	return Attributes{
+		values: make(map[string]string),
+		names:  make(map[string]string),
+	}
+}
+
+// Get returns the value for name, matched case-insensitively. The second
+// return value reports whether the attribute was present.
+func (a Attributes) Get(name string) (string, bool) {
+	v, ok := a.values[strings.ToLower(name)]
+	return v, ok
+}
+
+// set stores value under name, preserving the first-seen casing of name
+// for Names.
+func (a Attributes) set(name, value string) {
+	key := strings.ToLower(name)
+	if _, exists := a.names[key]; !exists {
+		a.names[key] = name
+	}
+	a.values[key] = value
+}
+
+// Names returns the attribute names in this section, in their original
+// casing, in no particular order.
+func (a Attributes) Names() []string {
+	names := make([]string, 0, len(a.names))
+	for _, n := range a.names {
+		names = append(names, n)
+	}
+	return names
+}
+
+// Len reports the number of attributes in this section.
+func (a Attributes) Len() int {
+	return len(a.values)
+}
+
+// Section is a single manifest section: the main section (Name == "") or
+// a per-entry section introduced by a "Name:" attribute.
+type Section struct {
+	Name       string
+	Attributes Attributes
+}
+
+// Manifest is a fully parsed MANIFEST.MF: a main section plus zero or more
+// per-entry sections, such as the digest sections found in signed JARs.
+type Manifest struct {
+	Main    Section
+	entries []Section
+}
+
+// Entries returns the per-entry sections of the manifest, i.e. every
+// section after the main section that is keyed by a "Name:" attribute.
+func (m *Manifest) Entries() []Section {
+	return m.entries
+}
+
+// Entry looks up a per-entry section by its Name attribute, matched
+// case-sensitively as JAR entry names are.
+func (m *Manifest) Entry(name string) (Section, bool) {
+	for _, e := range m.entries {
+		if e.Name == name {
+			return e, true
+		}
+	}
+	return Section{}, false
+}
+
+// Get looks up an attribute in the main section. It is a convenience
+// wrapper around m.Main.Attributes.Get.
+func (m *Manifest) Get(name string) (string, bool) {
+	return m.Main.Attributes.Get(name)
+}
+
+// normalizeLineEndings rewrites CRLF and CR line endings to LF, the only
+// convention the rest of this package deals with.
+func normalizeLineEndings(data []byte) []byte {
+	normalized := bytes.ReplaceAll(data, []byte("\r\n"), []byte("\n"))
+	return bytes.ReplaceAll(normalized, []byte("\r"), []byte("\n"))
+}
+
+// RawSection returns the raw bytes of the section identified by name (the
+// empty string for the main section), with line endings normalized to "\n"
+// but continuation lines left folded exactly as they appear in data. This
+// is the byte sequence that JAR signing tools hash to produce the
+// SHA-256-Digest-Manifest and per-entry SHA-256-Digest attributes recorded
+// in a META-INF/*.SF signature file, so callers verifying a signed JAR
+// should hash this rather than a re-serialized Section.
+func RawSection(data []byte, name string) ([]byte, bool) {
+	sections := splitRawSections(normalizeLineEndings(data))
+	if len(sections) == 0 {
+		return nil, false
+	}
+	if name == "" {
+		return sections[0], true
+	}
+	for _, sec := range sections[1:] {
+		if n, ok := rawSectionName(sec); ok && n == name {
+			return sec, true
+		}
+	}
+	return nil, false
+}
+
+// splitRawSections splits normalized manifest bytes into sections on blank
+// lines, without unfolding continuation lines, preserving the raw bytes of
+// each section.
+func splitRawSections(normalized []byte) [][]byte {
+	lines := bytes.Split(normalized, []byte("\n"))
+	var sections [][]byte
+	var current []byte
+	for _, line := range lines {
+		if len(line) == 0 {
+			if len(current) > 0 {
+				sections = append(sections, current)
+				current = nil
+			}
+			continue
+		}
+		current = append(current, line...)
+		current = append(current, '\n')
+	}
+	if len(current) > 0 {
+		sections = append(sections, current)
+	}
+	return sections
+}
+
+// rawSectionName extracts the value of a raw section's "Name:" line,
+// without unfolding continuations first. Per-entry section names are not
+// expected to need continuation folding in practice.
+func rawSectionName(sec []byte) (string, bool) {
+	for _, line := range bytes.Split(sec, []byte("\n")) {
+		s := string(line)
+		if len(s) >= 5 && strings.EqualFold(s[:5], "Name:") {
+			return strings.TrimSpace(s[5:]), true
+		}
+	}
+	return "", false
+}
+
+// Parse parses the raw bytes of a MANIFEST.MF file. It accepts CRLF, LF or
+// CR line endings and decodes attribute values as UTF-8.
+func Parse(data []byte) (*Manifest, error) {
+	normalized := normalizeLineEndings(data)
+
+	rawLines, err := unfoldContinuations(normalized)
+	if err != nil {
+		return nil, err
+	}
+
+	sections := splitSections(rawLines)
+	if len(sections) == 0 {
+		return &Manifest{Main: Section{Attributes: newAttributes()}}, nil
+	}
+
+	main, err := parseSection("", sections[0])
+	if err != nil {
+		return nil, fmt.Errorf("manifest: main section: %w", err)
+	}
+
+	m := &Manifest{Main: main}
+	for _, lines := range sections[1:] {
+		if len(lines) == 0 {
+			continue
+		}
+		sec, err := parseSection("", lines)
+		if err != nil {
+			return nil, fmt.Errorf("manifest: entry section: %w", err)
+		}
+		name, ok := sec.Attributes.Get("Name")
+		if !ok {
+			return nil, fmt.Errorf("manifest: entry section missing Name attribute")
+		}
+		sec.Name = name
+		m.entries = append(m.entries, sec)
+	}
+	return m, nil
+}
+
+// unfoldContinuations splits normalized manifest text into logical lines,
+// joining any line that starts with a single space onto the previous line
+// per the 72-byte continuation rule.
+func unfoldContinuations(normalized []byte) ([]string, error) {
+	scanner := bufio.NewScanner(bytes.NewReader(normalized))
+	scanner.Buffer(make([]byte, 0, 1024), 1024*1024)
+
+	var logical []string
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, " ") {
+			if len(logical) == 0 {
+				return nil, fmt.Errorf("manifest: continuation line with no preceding attribute")
+			}
+			logical[len(logical)-1] += line[1:]
+			continue
+		}
+		logical = append(logical, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("manifest: %w", err)
+	}
+	return logical, nil
+}
+
+// splitSections groups logical lines into sections, where a blank line
+// separates the main section from per-entry sections and each per-entry
+// section from the next.
+func splitSections(lines []string) [][]string {
+	var sections [][]string
+	var current []string
+	for _, line := range lines {
+		if line == "" {
+			if len(current) > 0 {
+				sections = append(sections, current)
+				current = nil
+			}
+			continue
+		}
+		current = append(current, line)
+	}
+	if len(current) > 0 {
+		sections = append(sections, current)
+	}
+	return sections
+}
+
+// parseSection parses the attribute lines of a single section.
+func parseSection(name string, lines []string) (Section, error) {
+	sec := Section{Name: name, Attributes: newAttributes()}
+	for _, line := range lines {
+		idx := strings.Index(line, ":")
+		if idx < 0 {
+			return sec, fmt.Errorf("invalid attribute line %q", line)
+		}
+		key := line[:idx]
+		value := strings.TrimPrefix(line[idx+1:], " ")
+		sec.Attributes.set(key, value)
+	}
+	return sec, nil
+}
@@ -0,0 +1,110 @@
+package manifest
+
+import "testing"
+
+func TestParse_MainSectionOnly(t *testing.T) {
+	data := "Manifest-Version: 1.0\r\nImplementation-Version: 1.2.3\r\n"
+	m, err := Parse([]byte(data))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if v, ok := m.Get("Implementation-Version"); !ok || v != "1.2.3" {
+		t.Errorf("expected Implementation-Version=1.2.3, got %q (ok=%v)", v, ok)
+	}
+	if v, ok := m.Get("implementation-version"); !ok || v != "1.2.3" {
+		t.Errorf("expected case-insensitive lookup to succeed, got %q (ok=%v)", v, ok)
+	}
+}
+
+func TestParse_ContinuationLines(t *testing.T) {
+	data := "Manifest-Version: 1.0\nClass-Path: a.jar b.jar\n  c.jar d.ja\n r\n"
+	m, err := Parse([]byte(data))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	v, ok := m.Get("Class-Path")
+	if !ok {
+		t.Fatalf("expected Class-Path attribute")
+	}
+	if v != "a.jar b.jar c.jar d.jar" {
+		t.Errorf("expected joined continuation value, got %q", v)
+	}
+}
+
+func TestParse_EntrySections(t *testing.T) {
+	data := "Manifest-Version: 1.0\n\n" +
+		"Name: com/example/Foo.class\n" +
+		"SHA-256-Digest: abc123\n\n" +
+		"Name: com/example/Bar.class\n" +
+		"SHA-256-Digest: def456\n"
+	m, err := Parse([]byte(data))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	entries := m.Entries()
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(entries))
+	}
+	foo, ok := m.Entry("com/example/Foo.class")
+	if !ok {
+		t.Fatalf("expected entry for Foo.class")
+	}
+	if v, _ := foo.Attributes.Get("SHA-256-Digest"); v != "abc123" {
+		t.Errorf("expected digest abc123, got %q", v)
+	}
+}
+
+func TestParse_CRLineEndings(t *testing.T) {
+	data := "Manifest-Version: 1.0\rImplementation-Version: 9.9.9\r"
+	m, err := Parse([]byte(data))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if v, ok := m.Get("Implementation-Version"); !ok || v != "9.9.9" {
+		t.Errorf("expected Implementation-Version=9.9.9, got %q (ok=%v)", v, ok)
+	}
+}
+
+func TestParse_EntryMissingName(t *testing.T) {
+	data := "Manifest-Version: 1.0\n\nSHA-256-Digest: abc123\n"
+	if _, err := Parse([]byte(data)); err == nil {
+		t.Errorf("expected error for entry section without Name attribute")
+	}
+}
+
+func TestParse_LeadingBlankLines(t *testing.T) {
+	data := "\nManifest-Version: 1.0\nImplementation-Version: 1.2.3\n"
+	m, err := Parse([]byte(data))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if v, ok := m.Get("Implementation-Version"); !ok || v != "1.2.3" {
+		t.Errorf("expected Implementation-Version=1.2.3, got %q (ok=%v)", v, ok)
+	}
+}
+
+func TestRawSection(t *testing.T) {
+	data := "Manifest-Version: 1.0\n\n" +
+		"Name: com/example/Foo.class\n" +
+		"SHA-256-Digest: abc123\n"
+
+	main, ok := RawSection([]byte(data), "")
+	if !ok {
+		t.Fatalf("expected to find main section")
+	}
+	if string(main) != "Manifest-Version: 1.0\n" {
+		t.Errorf("unexpected main section bytes: %q", main)
+	}
+
+	entry, ok := RawSection([]byte(data), "com/example/Foo.class")
+	if !ok {
+		t.Fatalf("expected to find entry section")
+	}
+	if string(entry) != "Name: com/example/Foo.class\nSHA-256-Digest: abc123\n" {
+		t.Errorf("unexpected entry section bytes: %q", entry)
+	}
+
+	if _, ok := RawSection([]byte(data), "com/example/Missing.class"); ok {
+		t.Errorf("expected no match for missing entry")
+	}
+}
@@ -0,0 +1,82 @@
+package version
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Constraint is a single comparator plus the version it compares against,
+// e.g. the ">=1.2.0" in ">=1.2.0 <2.0.0".
+type Constraint struct {
+	op      string
+	version Version
+}
+
+var operators = []string{">=", "<=", "==", ">", "<", "="}
+
+// ParseConstraints parses a space-separated list of comparator
+// expressions (e.g. ">=1.2.0 <2.0.0") into Constraints that must all hold
+// (logical AND) for a version to satisfy them. A bare version with no
+// operator is treated as "==".
+func ParseConstraints(expr string) ([]Constraint, error) {
+	fields := strings.Fields(expr)
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("version: empty constraint expression")
+	}
+
+	constraints := make([]Constraint, 0, len(fields))
+	for _, field := range fields {
+		op := "=="
+		rest := field
+		for _, candidate := range operators {
+			if strings.HasPrefix(field, candidate) {
+				op = candidate
+				rest = field[len(candidate):]
+				break
+			}
+		}
+		if op == "=" {
+			op = "=="
+		}
+
+		v, err := Parse(rest)
+		if err != nil {
+			return nil, fmt.Errorf("version: invalid constraint %q: %w", field, err)
+		}
+		constraints = append(constraints, Constraint{op: op, version: v})
+	}
+	return constraints, nil
+}
+
+// Satisfies reports whether v satisfies every constraint in expr.
+func Satisfies(v Version, expr string) (bool, error) {
+	constraints, err := ParseConstraints(expr)
+	if err != nil {
+		return false, err
+	}
+	for _, c := range constraints {
+		if !c.Matches(v) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// Matches reports whether v satisfies this single constraint.
+func (c Constraint) Matches(v Version) bool {
+	cmp := v.Compare(c.version)
+	switch c.op {
+	case ">=":
+		return cmp >= 0
+	case "<=":
+		return cmp <= 0
+	case ">":
+		return cmp > 0
+	case "<":
+		return cmp < 0
+	case "==":
+		return cmp == 0
+	default:
+		return false
+	}
+}
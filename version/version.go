@@ -0,0 +1,212 @@
+// Package version parses and compares the version strings found in JAR
+// manifests. It is tolerant of the Maven conventions seen in practice:
+// a missing patch segment ("1.2" -> "1.2.0"), a "-SNAPSHOT" suffix, and a
+// four-segment form ("1.2.3.4").
+package version
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Kind identifies which segment Next should bump.
+type Kind string
+
+const (
+	Major Kind = "major"
+	Minor Kind = "minor"
+	Patch Kind = "patch"
+)
+
+// Version is a parsed, comparable version number.
+type Version struct {
+	Major, Minor, Patch int
+
+	// Revision is the optional fourth numeric segment, as seen in
+	// "1.2.3.4"-style versions. HasRevision reports whether it was present.
+	Revision    int
+	HasRevision bool
+
+	// Pre is the pre-release identifier, e.g. "SNAPSHOT" or "alpha.1",
+	// without its leading "-". Empty for a release version.
+	Pre string
+
+	// Build is build metadata, e.g. "build.7", without its leading "+". It
+	// is carried through String but ignored by Compare, per semver.
+	Build string
+}
+
+var versionPattern = regexp.MustCompile(
+	`^(\d+)(?:\.(\d+))?(?:\.(\d+))?(?:\.(\d+))?(?:-([0-9A-Za-z.-]+))?(?:\+([0-9A-Za-z.-]+))?$`,
+)
+
+// Parse parses s into a Version. A missing minor or patch segment is
+// normalized to 0.
+func Parse(s string) (Version, error) {
+	s = strings.TrimSpace(s)
+	m := versionPattern.FindStringSubmatch(s)
+	if m == nil {
+		return Version{}, fmt.Errorf("version: invalid version %q", s)
+	}
+
+	var v Version
+	var err error
+	if v.Major, err = atoi(m[1]); err != nil {
+		return Version{}, err
+	}
+	if m[2] != "" {
+		if v.Minor, err = atoi(m[2]); err != nil {
+			return Version{}, err
+		}
+	}
+	if m[3] != "" {
+		if v.Patch, err = atoi(m[3]); err != nil {
+			return Version{}, err
+		}
+	}
+	if m[4] != "" {
+		if v.Revision, err = atoi(m[4]); err != nil {
+			return Version{}, err
+		}
+		v.HasRevision = true
+	}
+	v.Pre = m[5]
+	v.Build = m[6]
+	return v, nil
+}
+
+func atoi(s string) (int, error) {
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("version: invalid numeric segment %q", s)
+	}
+	return n, nil
+}
+
+// String renders the version back to its canonical textual form.
+func (v Version) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if v.HasRevision {
+		fmt.Fprintf(&b, ".%d", v.Revision)
+	}
+	if v.Pre != "" {
+		b.WriteString("-")
+		b.WriteString(v.Pre)
+	}
+	if v.Build != "" {
+		b.WriteString("+")
+		b.WriteString(v.Build)
+	}
+	return b.String()
+}
+
+// IsSnapshot reports whether v carries a Maven "-SNAPSHOT" pre-release
+// identifier.
+func (v Version) IsSnapshot() bool {
+	return strings.EqualFold(v.Pre, "SNAPSHOT")
+}
+
+// IsPrerelease reports whether v has any pre-release identifier,
+// including but not limited to "-SNAPSHOT".
+func (v Version) IsPrerelease() bool {
+	return v.Pre != ""
+}
+
+// Next returns the version produced by bumping the given segment and
+// resetting all lower-precedence segments (including any pre-release and
+// build metadata).
+func (v Version) Next(kind Kind) (Version, error) {
+	switch kind {
+	case Major:
+		return Version{Major: v.Major + 1}, nil
+	case Minor:
+		return Version{Major: v.Major, Minor: v.Minor + 1}, nil
+	case Patch:
+		return Version{Major: v.Major, Minor: v.Minor, Patch: v.Patch + 1}, nil
+	default:
+		return Version{}, fmt.Errorf("version: unknown bump kind %q", kind)
+	}
+}
+
+// Compare returns -1, 0 or 1 if v is respectively less than, equal to, or
+// greater than other. The numeric major.minor.patch[.revision] core is
+// compared first; a pre-release version has lower precedence than the
+// same core without one (so "1.2.3-SNAPSHOT" < "1.2.3"). Build metadata
+// does not affect precedence.
+func (v Version) Compare(other Version) int {
+	if c := compareInt(v.Major, other.Major); c != 0 {
+		return c
+	}
+	if c := compareInt(v.Minor, other.Minor); c != 0 {
+		return c
+	}
+	if c := compareInt(v.Patch, other.Patch); c != 0 {
+		return c
+	}
+	if c := compareInt(v.Revision, other.Revision); c != 0 {
+		return c
+	}
+
+	switch {
+	case v.Pre == "" && other.Pre == "":
+		return 0
+	case v.Pre == "" && other.Pre != "":
+		return 1
+	case v.Pre != "" && other.Pre == "":
+		return -1
+	default:
+		return comparePre(v.Pre, other.Pre)
+	}
+}
+
+func compareInt(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// comparePre compares two pre-release strings identifier-by-identifier
+// using the semver 2.0.0 rules: dot-separated fields are compared
+// numerically when both are numeric, and lexically otherwise, with a
+// shorter field list ranking lower when it is a prefix of the longer one.
+func comparePre(a, b string) int {
+	aParts := strings.Split(a, ".")
+	bParts := strings.Split(b, ".")
+
+	for i := 0; i < len(aParts) && i < len(bParts); i++ {
+		ap, aIsNum := toNumeric(aParts[i])
+		bp, bIsNum := toNumeric(bParts[i])
+
+		switch {
+		case aIsNum && bIsNum:
+			if c := compareInt(ap, bp); c != 0 {
+				return c
+			}
+		case aIsNum && !bIsNum:
+			return -1
+		case !aIsNum && bIsNum:
+			return 1
+		default:
+			if c := strings.Compare(aParts[i], bParts[i]); c != 0 {
+				return c
+			}
+		}
+	}
+	return compareInt(len(aParts), len(bParts))
+}
+
+func toNumeric(s string) (int, bool) {
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, false
+	}
+	return n, true
+}
@@ -0,0 +1,119 @@
+package version
+
+import "testing"
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		input string
+		want  Version
+	}{
+		{"1.2.3", Version{Major: 1, Minor: 2, Patch: 3}},
+		{"1.2", Version{Major: 1, Minor: 2, Patch: 0}},
+		{"1", Version{Major: 1}},
+		{"1.2.3-SNAPSHOT", Version{Major: 1, Minor: 2, Patch: 3, Pre: "SNAPSHOT"}},
+		{"1.2.3.4", Version{Major: 1, Minor: 2, Patch: 3, Revision: 4, HasRevision: true}},
+		{"1.2.3+build.7", Version{Major: 1, Minor: 2, Patch: 3, Build: "build.7"}},
+	}
+	for _, tt := range tests {
+		got, err := Parse(tt.input)
+		if err != nil {
+			t.Fatalf("Parse(%q) failed: %v", tt.input, err)
+		}
+		if got != tt.want {
+			t.Errorf("Parse(%q) = %+v, want %+v", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	if _, err := Parse("not-a-version"); err == nil {
+		t.Errorf("expected error for invalid version")
+	}
+}
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"1.2.3", "1.2.3", 0},
+		{"1.2.4", "1.2.3", 1},
+		{"1.2.3", "1.2.4", -1},
+		{"1.2.3-SNAPSHOT", "1.2.3", -1},
+		{"1.2.3", "1.2.3-SNAPSHOT", 1},
+		{"1.2.3-alpha", "1.2.3-beta", -1},
+		{"1.2.3-alpha.1", "1.2.3-alpha", 1},
+		{"2.0.0", "1.9.9", 1},
+	}
+	for _, tt := range tests {
+		a, err := Parse(tt.a)
+		if err != nil {
+			t.Fatalf("Parse(%q) failed: %v", tt.a, err)
+		}
+		b, err := Parse(tt.b)
+		if err != nil {
+			t.Fatalf("Parse(%q) failed: %v", tt.b, err)
+		}
+		if got := a.Compare(b); got != tt.want {
+			t.Errorf("Compare(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestIsSnapshotAndPrerelease(t *testing.T) {
+	v, _ := Parse("1.2.3-SNAPSHOT")
+	if !v.IsSnapshot() || !v.IsPrerelease() {
+		t.Errorf("expected 1.2.3-SNAPSHOT to be a snapshot prerelease")
+	}
+
+	v, _ = Parse("1.2.3")
+	if v.IsSnapshot() || v.IsPrerelease() {
+		t.Errorf("expected 1.2.3 to be neither snapshot nor prerelease")
+	}
+}
+
+func TestNext(t *testing.T) {
+	v, _ := Parse("1.2.3-SNAPSHOT")
+
+	major, err := v.Next(Major)
+	if err != nil || major.String() != "2.0.0" {
+		t.Errorf("Next(Major) = %v, %v, want 2.0.0", major, err)
+	}
+
+	minor, err := v.Next(Minor)
+	if err != nil || minor.String() != "1.3.0" {
+		t.Errorf("Next(Minor) = %v, %v, want 1.3.0", minor, err)
+	}
+
+	patch, err := v.Next(Patch)
+	if err != nil || patch.String() != "1.2.4" {
+		t.Errorf("Next(Patch) = %v, %v, want 1.2.4", patch, err)
+	}
+}
+
+func TestSatisfies(t *testing.T) {
+	tests := []struct {
+		version    string
+		constraint string
+		want       bool
+	}{
+		{"1.5.0", ">=1.2.0 <2.0.0", true},
+		{"2.0.0", ">=1.2.0 <2.0.0", false},
+		{"1.2.0", ">=1.2.0 <2.0.0", true},
+		{"1.2.3", "1.2.3", true},
+		{"1.2.4", "1.2.3", false},
+	}
+	for _, tt := range tests {
+		v, err := Parse(tt.version)
+		if err != nil {
+			t.Fatalf("Parse(%q) failed: %v", tt.version, err)
+		}
+		got, err := Satisfies(v, tt.constraint)
+		if err != nil {
+			t.Fatalf("Satisfies(%q, %q) failed: %v", tt.version, tt.constraint, err)
+		}
+		if got != tt.want {
+			t.Errorf("Satisfies(%q, %q) = %v, want %v", tt.version, tt.constraint, got, tt.want)
+		}
+	}
+}